@@ -0,0 +1,95 @@
+package firecrawl
+
+import "time"
+
+// Config holds the settings of a FirecrawlApp as a plain struct, for configuration loaded from
+// files or the environment. Zero values keep the defaults of NewFirecrawlApp and its options.
+type Config struct {
+	// APIKey and APIURL are passed to NewFirecrawlApp, which falls back to the FIRECRAWL_API_KEY
+	// and FIRECRAWL_API_URL environment variables when they are empty.
+	APIKey string
+	APIURL string
+
+	// Timeout is the timeout of the HTTP client. The default is 60 seconds.
+	Timeout time.Duration
+
+	// StatusCheckRetries and StatusCheckBackoff set the retry policy of job status checks, see
+	// WithStatusCheckRetries. StatusCheckJitter enables WithStatusCheckJitter.
+	StatusCheckRetries int
+	StatusCheckBackoff time.Duration
+	StatusCheckJitter  bool
+
+	// MaxBackoff caps the interval between retries, see WithMaxBackoff.
+	MaxBackoff time.Duration
+
+	// MaxResponseSize is the maximum size in bytes of a response body, see WithMaxResponseSize.
+	MaxResponseSize int64
+
+	// MaxStatusPages is the maximum number of status pages fetched for a job, see WithMaxStatusPages.
+	MaxStatusPages int
+
+	// RateLimit and RateLimitBurst limit the rate of requests, see WithRateLimit.
+	RateLimit      float64
+	RateLimitBurst int
+
+	// Origin tags requests with the X-Origin header, see WithOrigin.
+	Origin string
+
+	// RequestCompressionThreshold is the minimum size in bytes of compressed request bodies, see
+	// WithRequestCompression.
+	RequestCompressionThreshold int
+
+	// DebugRequestBodies records rejected request bodies in API errors, see WithDebugRequestBodies.
+	DebugRequestBodies bool
+}
+
+// NewFirecrawlAppFromConfig creates a new instance of FirecrawlApp from a Config. The options set by
+// the config are applied first, so opts can add settings the config does not hold, such as a
+// MetricsCollector, or override it.
+//
+// Parameters:
+//   - cfg: The configuration of the app.
+//   - opts: Optional app options, applied after the config.
+//
+// Returns:
+//   - *FirecrawlApp: A new instance of FirecrawlApp configured from cfg.
+//   - error: An error if the API key is not provided or retrieved.
+func NewFirecrawlAppFromConfig(cfg Config, opts ...AppOption) (*FirecrawlApp, error) {
+	var configOpts []AppOption
+	if cfg.Timeout > 0 {
+		configOpts = append(configOpts, func(app *FirecrawlApp) {
+			client := *app.Client
+			client.Timeout = cfg.Timeout
+			app.Client = &client
+		})
+	}
+	if cfg.StatusCheckRetries > 0 || cfg.StatusCheckBackoff > 0 {
+		configOpts = append(configOpts, WithStatusCheckRetries(cfg.StatusCheckRetries, int(cfg.StatusCheckBackoff/time.Millisecond)))
+	}
+	if cfg.StatusCheckJitter {
+		configOpts = append(configOpts, WithStatusCheckJitter(true))
+	}
+	if cfg.MaxBackoff > 0 {
+		configOpts = append(configOpts, WithMaxBackoff(cfg.MaxBackoff))
+	}
+	if cfg.MaxResponseSize > 0 {
+		configOpts = append(configOpts, WithMaxResponseSize(cfg.MaxResponseSize))
+	}
+	if cfg.MaxStatusPages > 0 {
+		configOpts = append(configOpts, WithMaxStatusPages(cfg.MaxStatusPages))
+	}
+	if cfg.RateLimit > 0 {
+		configOpts = append(configOpts, WithRateLimit(cfg.RateLimit, cfg.RateLimitBurst))
+	}
+	if cfg.Origin != "" {
+		configOpts = append(configOpts, WithOrigin(cfg.Origin))
+	}
+	if cfg.RequestCompressionThreshold > 0 {
+		configOpts = append(configOpts, WithRequestCompression(cfg.RequestCompressionThreshold))
+	}
+	if cfg.DebugRequestBodies {
+		configOpts = append(configOpts, WithDebugRequestBodies(true))
+	}
+
+	return NewFirecrawlApp(cfg.APIKey, cfg.APIURL, append(configOpts, opts...)...)
+}
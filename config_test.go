@@ -0,0 +1,47 @@
+package firecrawl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFirecrawlAppFromConfig(t *testing.T) {
+	app, err := NewFirecrawlAppFromConfig(Config{
+		APIKey:             "fc-test",
+		APIURL:             "https://firecrawl.internal",
+		Timeout:            2 * time.Minute,
+		StatusCheckRetries: 5,
+		StatusCheckBackoff: time.Second,
+		MaxBackoff:         30 * time.Second,
+		MaxResponseSize:    10 << 20,
+		RateLimit:          5,
+		RateLimitBurst:     10,
+		Origin:             "indexer",
+	}, WithOrigin("override"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "fc-test", app.APIKey)
+	assert.Equal(t, "https://firecrawl.internal", app.APIURL)
+	assert.Equal(t, 2*time.Minute, app.Client.Timeout)
+	assert.Equal(t, 5, app.statusCheckRetries)
+	assert.Equal(t, 1000, app.statusCheckBackoff)
+	assert.Equal(t, 30*time.Second, app.maxBackoff)
+	assert.Equal(t, int64(10<<20), app.maxResponseSize)
+	assert.NotNil(t, app.rateLimiter)
+	assert.Equal(t, "override", app.origin)
+}
+
+func TestNewFirecrawlAppFromConfigDefaults(t *testing.T) {
+	app, err := NewFirecrawlAppFromConfig(Config{APIKey: "fc-test"})
+	require.NoError(t, err)
+
+	defaults, err := NewFirecrawlApp("fc-test", "")
+	require.NoError(t, err)
+	assert.Equal(t, defaults.APIURL, app.APIURL)
+	assert.Equal(t, defaults.Client.Timeout, app.Client.Timeout)
+	assert.Nil(t, app.rateLimiter)
+	assert.Zero(t, app.statusCheckRetries)
+}
@@ -0,0 +1,703 @@
+package firecrawl
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"unicode"
+
+	nethtml "golang.org/x/net/html"
+)
+
+// ParseHTML parses the document's HTML into a node tree with golang.org/x/net/html, for
+// selecting elements beyond what the other helpers of the document cover.
+//
+// Returns:
+//   - *nethtml.Node: The root node of the parsed HTML.
+//   - error: An error if the document has no HTML, for example because the "html" format was not requested.
+func (doc *FirecrawlDocument) ParseHTML() (*nethtml.Node, error) {
+	if doc == nil || doc.HTML == "" {
+		return nil, fmt.Errorf("document has no HTML content; request the \"html\" format to receive it")
+	}
+	return nethtml.Parse(strings.NewReader(doc.HTML))
+}
+
+// Redirected reports whether the scraped page was reached through a redirect, that is
+// whether its resolved URL differs from the requested one.
+//
+// Returns:
+//   - bool: True if both URLs are known and differ.
+func (m *FirecrawlDocumentMetadata) Redirected() bool {
+	if m == nil || m.SourceURL == nil || m.ResolvedURL == nil {
+		return false
+	}
+	return *m.SourceURL != *m.ResolvedURL
+}
+
+// documentFormats lists the JSON fields of FirecrawlDocument that hold requested formats.
+var documentFormats = []string{"markdown", "html", "rawHtml", "screenshot", "links"}
+
+// documentJSON is FirecrawlDocument without its JSON methods.
+type documentJSON FirecrawlDocument
+
+// HasFormat reports whether the document was decoded from a response that contained the given
+// format ("markdown", "html", "rawHtml", "screenshot" or "links"), even if its value was empty.
+// This distinguishes a format that was not requested from one that was returned empty.
+//
+// Parameters:
+//   - format: The name of the format.
+//
+// Returns:
+//   - bool: True if the format was present in the decoded response.
+func (doc *FirecrawlDocument) HasFormat(format string) bool {
+	return doc != nil && doc.presentFormats[format]
+}
+
+// UnmarshalJSON decodes a document and records which formats were present in the JSON.
+func (doc *FirecrawlDocument) UnmarshalJSON(data []byte) error {
+	// The format fields shadow those of the embedded document, so they are decoded into pointers
+	// that record their presence, in the same pass as the other fields.
+	*doc = FirecrawlDocument{}
+	decoded := struct {
+		*documentJSON
+		Markdown   *string   `json:"markdown"`
+		HTML       *string   `json:"html"`
+		RawHTML    *string   `json:"rawHtml"`
+		Screenshot *string   `json:"screenshot"`
+		Links      *[]string `json:"links"`
+	}{documentJSON: (*documentJSON)(doc)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	present := func(format string) {
+		if doc.presentFormats == nil {
+			doc.presentFormats = map[string]bool{}
+		}
+		doc.presentFormats[format] = true
+	}
+	if decoded.Markdown != nil {
+		doc.Markdown = *decoded.Markdown
+		present("markdown")
+	}
+	if decoded.HTML != nil {
+		doc.HTML = *decoded.HTML
+		present("html")
+	}
+	if decoded.RawHTML != nil {
+		doc.RawHTML = *decoded.RawHTML
+		present("rawHtml")
+	}
+	if decoded.Screenshot != nil {
+		doc.Screenshot = *decoded.Screenshot
+		present("screenshot")
+	}
+	if decoded.Links != nil {
+		doc.Links = *decoded.Links
+		present("links")
+	}
+	return nil
+}
+
+// MarshalJSON encodes a document, keeping formats that were present when it was decoded even if
+// they are empty, so that a decoded document round-trips to the same set of formats.
+func (doc FirecrawlDocument) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(documentJSON(doc))
+	if err != nil || len(doc.presentFormats) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, format := range documentFormats {
+		if _, ok := fields[format]; ok || !doc.presentFormats[format] {
+			continue
+		}
+		if format == "links" {
+			fields[format] = json.RawMessage(`[]`)
+		} else {
+			fields[format] = json.RawMessage(`""`)
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// baseURL returns the URL relative links in the document are resolved against: the page's
+// resolved URL, or the requested one if unknown.
+//
+// Returns:
+//   - *url.URL: The page URL, or nil if the document has none.
+func (doc *FirecrawlDocument) baseURL() *url.URL {
+	if doc.Metadata == nil {
+		return nil
+	}
+	pageURL := doc.Metadata.ResolvedURL
+	if pageURL == nil {
+		pageURL = doc.Metadata.SourceURL
+	}
+	if pageURL == nil {
+		return nil
+	}
+	base, err := url.Parse(*pageURL)
+	if err != nil {
+		return nil
+	}
+	return base
+}
+
+// sourceHTML returns the document's raw HTML, or its HTML if the "rawHtml" format was not requested.
+//
+// Returns:
+//   - string: The HTML of the document, empty if neither format was returned.
+func (doc *FirecrawlDocument) sourceHTML() string {
+	if doc.RawHTML != "" {
+		return doc.RawHTML
+	}
+	return doc.HTML
+}
+
+// htmlElements returns the attributes of the elements with the given tag name in the document's
+// HTML, keyed by lowercase attribute name. The HTML is tokenized, so tags inside comments and in
+// the text of script and style elements are ignored. Attribute values are unescaped and trimmed.
+//
+// Parameters:
+//   - tagName: The lowercase tag name of the elements, e.g. "link".
+//
+// Returns:
+//   - []map[string]string: The attributes of each element, in document order.
+func (doc *FirecrawlDocument) htmlElements(tagName string) []map[string]string {
+	var elements []map[string]string
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(doc.sourceHTML()))
+	for {
+		switch tokenizer.Next() {
+		case nethtml.ErrorToken:
+			return elements
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			name, hasAttributes := tokenizer.TagName()
+			if string(name) != tagName {
+				continue
+			}
+			attributes := make(map[string]string)
+			for hasAttributes {
+				var key, value []byte
+				key, value, hasAttributes = tokenizer.TagAttr()
+				if _, ok := attributes[string(key)]; !ok {
+					attributes[string(key)] = strings.TrimSpace(string(value))
+				}
+			}
+			elements = append(elements, attributes)
+		}
+	}
+}
+
+// hasLinkType reports whether a rel attribute value contains the given link type.
+//
+// Parameters:
+//   - rel: The rel attribute value, a space-separated list of link types.
+//   - linkType: The link type to look for.
+//
+// Returns:
+//   - bool: True if the link type is present, ignoring case.
+func hasLinkType(rel, linkType string) bool {
+	for _, r := range strings.Fields(rel) {
+		if strings.EqualFold(r, linkType) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveURL resolves a URL found in the document against the page URL. URLs that cannot be
+// parsed, and all URLs of a document without a page URL, are returned unchanged.
+//
+// Parameters:
+//   - href: The URL to resolve.
+//
+// Returns:
+//   - string: The resolved URL.
+func (doc *FirecrawlDocument) resolveURL(href string) string {
+	if base := doc.baseURL(); base != nil {
+		if ref, err := url.Parse(href); err == nil {
+			return base.ResolveReference(ref).String()
+		}
+	}
+	return href
+}
+
+// CanonicalURL returns the page's canonical URL, declared by its <link rel="canonical"> element and
+// resolved against the page URL. The API does not report it in the metadata, so it is read from the
+// document's raw HTML, or its HTML if the "rawHtml" format was not requested.
+//
+// Returns:
+//   - string: The canonical URL.
+//   - bool: False if the page declares no canonical URL or its HTML was not returned.
+func (doc *FirecrawlDocument) CanonicalURL() (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+	for _, link := range doc.htmlElements("link") {
+		if hasLinkType(link["rel"], "canonical") && link["href"] != "" {
+			return doc.resolveURL(link["href"]), true
+		}
+	}
+	return "", false
+}
+
+// JSONLD returns the structured data the page embeds in <script type="application/ld+json">
+// elements, such as schema.org Product or Article data, one entry per element. Elements whose content
+// is not valid JSON are skipped. The API does not return structured data, so, like CanonicalURL, it
+// is read from the document's raw HTML, or its HTML if the "rawHtml" format was not requested; the
+// "html" format may have the scripts removed, so request "rawHtml" to get them reliably.
+//
+// Returns:
+//   - []json.RawMessage: The JSON-LD data, in document order, or nil if the page embeds none.
+func (doc *FirecrawlDocument) JSONLD() []json.RawMessage {
+	if doc == nil {
+		return nil
+	}
+	var blocks []json.RawMessage
+	tokenizer := nethtml.NewTokenizer(strings.NewReader(doc.sourceHTML()))
+	for {
+		switch tokenizer.Next() {
+		case nethtml.ErrorToken:
+			return blocks
+		case nethtml.StartTagToken:
+			name, hasAttributes := tokenizer.TagName()
+			if string(name) != "script" {
+				continue
+			}
+			isJSONLD := false
+			for hasAttributes {
+				var key, value []byte
+				key, value, hasAttributes = tokenizer.TagAttr()
+				if string(key) == "type" {
+					isJSONLD = strings.EqualFold(strings.TrimSpace(string(value)), "application/ld+json")
+					break
+				}
+			}
+			if !isJSONLD || tokenizer.Next() != nethtml.TextToken {
+				continue
+			}
+			block := strings.TrimSpace(string(tokenizer.Text()))
+			if json.Valid([]byte(block)) {
+				blocks = append(blocks, json.RawMessage(block))
+			}
+		}
+	}
+}
+
+// Authors returns the names of the page's authors, declared by its <meta name="author"> elements and
+// the "author" properties of its JSON-LD data (e.g. an Article's author Person). Like CanonicalURL, it
+// reads the document's raw HTML, or its HTML if the "rawHtml" format was not requested. The API does
+// not report authors in the metadata.
+//
+// Returns:
+//   - []string: The author names without duplicates, meta tags first, or nil if none are declared.
+func (doc *FirecrawlDocument) Authors() []string {
+	if doc == nil {
+		return nil
+	}
+
+	var authors []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			authors = append(authors, name)
+		}
+	}
+
+	for _, meta := range doc.htmlElements("meta") {
+		if strings.EqualFold(meta["name"], "author") {
+			add(meta["content"])
+		}
+	}
+	for _, block := range doc.JSONLD() {
+		var data any
+		if err := json.Unmarshal(block, &data); err != nil {
+			continue
+		}
+		for _, name := range jsonLDAuthors(data) {
+			add(html.UnescapeString(name))
+		}
+	}
+	return authors
+}
+
+// jsonLDAuthors returns the author names of the JSON-LD items in data, which is a single item, an
+// array of items or a document with an "@graph" of items.
+//
+// Parameters:
+//   - data: The decoded JSON-LD data.
+//
+// Returns:
+//   - []string: The author names.
+func jsonLDAuthors(data any) []string {
+	var names []string
+	switch value := data.(type) {
+	case []any:
+		for _, item := range value {
+			names = append(names, jsonLDAuthors(item)...)
+		}
+	case map[string]any:
+		names = append(names, jsonLDNames(value["author"])...)
+		names = append(names, jsonLDAuthors(value["@graph"])...)
+	}
+	return names
+}
+
+// jsonLDNames returns the names in a JSON-LD author value: a name, a Person or Organization with a
+// name, or an array of them.
+//
+// Parameters:
+//   - author: The decoded author value.
+//
+// Returns:
+//   - []string: The names.
+func jsonLDNames(author any) []string {
+	switch value := author.(type) {
+	case string:
+		return []string{value}
+	case map[string]any:
+		if name, ok := value["name"].(string); ok {
+			return []string{name}
+		}
+	case []any:
+		var names []string
+		for _, item := range value {
+			names = append(names, jsonLDNames(item)...)
+		}
+		return names
+	}
+	return nil
+}
+
+// AlternateURLs returns the language variants of the page, declared by its
+// <link rel="alternate" hreflang="..."> elements, as a map from language code (as declared, e.g.
+// "de", "en-GB" or "x-default") to URL resolved against the page URL. Like CanonicalURL, it reads
+// the document's raw HTML, or its HTML if the "rawHtml" format was not requested. If a language is
+// declared more than once, the first URL is kept.
+//
+// Returns:
+//   - map[string]string: The alternate URLs by language, or nil if the page declares none.
+func (doc *FirecrawlDocument) AlternateURLs() map[string]string {
+	if doc == nil {
+		return nil
+	}
+	var alternates map[string]string
+	for _, link := range doc.htmlElements("link") {
+		lang, href := link["hreflang"], link["href"]
+		if !hasLinkType(link["rel"], "alternate") || lang == "" || href == "" {
+			continue
+		}
+		if alternates == nil {
+			alternates = make(map[string]string)
+		}
+		if _, ok := alternates[lang]; !ok {
+			alternates[lang] = doc.resolveURL(href)
+		}
+	}
+	return alternates
+}
+
+// IframeURLs returns the URLs of the iframes embedded in the document, resolved against the page URL.
+// The API does not scrape iframe content into the page, so to get it, scrape these URLs directly.
+// The document must have been scraped with the "rawHtml" or "html" format.
+//
+// Returns:
+//   - []string: The iframe URLs, in document order and without duplicates.
+func (doc *FirecrawlDocument) IframeURLs() []string {
+	if doc == nil {
+		return nil
+	}
+	base := doc.baseURL()
+
+	var urls []string
+	seen := map[string]bool{}
+	for _, iframe := range doc.htmlElements("iframe") {
+		src := iframe["src"]
+		if src == "" || strings.HasPrefix(src, "about:") || strings.HasPrefix(src, "javascript:") {
+			continue
+		}
+		if base != nil {
+			if ref, err := url.Parse(src); err == nil {
+				src = base.ResolveReference(ref).String()
+			}
+		}
+		if !seen[src] {
+			seen[src] = true
+			urls = append(urls, src)
+		}
+	}
+	return urls
+}
+
+// Tables returns the tables in the document's markdown as rows of cells. See ParseMarkdownTables.
+//
+// Returns:
+//   - []MarkdownTable: The tables, in the order they appear in the document.
+func (doc *FirecrawlDocument) Tables() []MarkdownTable {
+	if doc == nil {
+		return nil
+	}
+	return ParseMarkdownTables(doc.Markdown)
+}
+
+// PlainText returns the document's markdown converted to plain text, for uses such as text-to-speech
+// or search indexing. See StripMarkdown.
+//
+// Returns:
+//   - string: The plain text of the document.
+func (doc *FirecrawlDocument) PlainText() string {
+	if doc == nil {
+		return ""
+	}
+	return StripMarkdown(doc.Markdown)
+}
+
+// ChunkMarkdown splits the document's markdown into chunks of at most maxChars characters, cutting
+// at headings first. The API always returns a page's content in one piece. See ChunkMarkdown.
+//
+// Parameters:
+//   - maxChars: The maximum number of characters of a chunk.
+//
+// Returns:
+//   - []string: The chunks, in order.
+func (doc *FirecrawlDocument) ChunkMarkdown(maxChars int) []string {
+	if doc == nil {
+		return nil
+	}
+	return ChunkMarkdown(doc.Markdown, maxChars)
+}
+
+// charsPerToken is the average number of characters per token of English text for common LLM
+// tokenizers, used by EstimateTokens.
+const charsPerToken = 4
+
+// EstimateTokens returns an approximate number of LLM tokens in the document's markdown, for sizing
+// batches of content sent to a model. It assumes 4 characters per token, except for Chinese,
+// Japanese and Korean characters, which count as one token each. Actual counts depend on the
+// model's tokenizer and can differ noticeably, especially for code and non-English text.
+//
+// Returns:
+//   - int: The estimated number of tokens.
+func (doc *FirecrawlDocument) EstimateTokens() int {
+	if doc == nil {
+		return 0
+	}
+	cjk, other := 0, 0
+	for _, r := range doc.Markdown {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			cjk++
+		} else {
+			other++
+		}
+	}
+	return cjk + (other+charsPerToken-1)/charsPerToken
+}
+
+// DeadLinks returns the URLs of the crawled pages that responded with an HTTP error status (400 or
+// above), as reported in each document's metadata. The API does not check links the crawl did not
+// follow, such as external links unless AllowExternalLinks is set, so those are not covered; pages
+// that failed without a response are listed by CheckCrawlErrors instead.
+//
+// Returns:
+//   - []string: The source URLs of the pages with an error status, in crawl order.
+func (r *CrawlStatusResponse) DeadLinks() []string {
+	if r == nil {
+		return nil
+	}
+
+	var links []string
+	for _, doc := range r.Data {
+		if doc == nil || doc.Metadata == nil || doc.Metadata.StatusCode == nil || *doc.Metadata.StatusCode < 400 {
+			continue
+		}
+		switch {
+		case doc.Metadata.SourceURL != nil:
+			links = append(links, *doc.Metadata.SourceURL)
+		case doc.Metadata.ResolvedURL != nil:
+			links = append(links, *doc.Metadata.ResolvedURL)
+		}
+	}
+	return links
+}
+
+// DocumentsFor returns the documents of a batch scrape in the order of the given URLs, matching each
+// document to the URL it was requested for through its Metadata.SourceURL. The API returns documents
+// in no guaranteed order and omits URLs that failed, so the entry of a URL without a document is nil.
+// Combined with HasFormat, this tells which formats each requested URL actually received.
+//
+// Parameters:
+//   - urls: The URLs the batch scrape was started with.
+//
+// Returns:
+//   - []*FirecrawlDocument: The document of each URL, or nil if there is none, indexed like urls.
+func (r *BatchScrapeStatusResponse) DocumentsFor(urls []string) []*FirecrawlDocument {
+	byURL := make(map[string][]*FirecrawlDocument)
+	if r != nil {
+		for _, doc := range r.Data {
+			if doc != nil && doc.Metadata != nil && doc.Metadata.SourceURL != nil {
+				byURL[*doc.Metadata.SourceURL] = append(byURL[*doc.Metadata.SourceURL], doc)
+			}
+		}
+	}
+
+	documents := make([]*FirecrawlDocument, len(urls))
+	for i, u := range urls {
+		if docs := byURL[u]; len(docs) > 0 {
+			documents[i] = docs[0]
+			byURL[u] = docs[1:]
+		}
+	}
+	return documents
+}
+
+// DocumentDiff represents the line-level differences between the markdown of two scrapes of a page.
+type DocumentDiff struct {
+	Added      []string `json:"added"`
+	Removed    []string `json:"removed"`
+	Similarity float64  `json:"similarity"`
+}
+
+// Changed reports whether any markdown line was added or removed.
+//
+// Returns:
+//   - bool: True if the documents differ.
+func (diff *DocumentDiff) Changed() bool {
+	return diff != nil && (len(diff.Added) > 0 || len(diff.Removed) > 0)
+}
+
+// DiffDocuments compares the markdown of two scrapes of a page. Both markdowns are normalized with
+// CollapseWhitespace and blank lines are ignored, so whitespace-only changes are not reported.
+// The similarity is the share of lines the documents have in common, from 0 (nothing in common)
+// to 1 (identical).
+//
+// Parameters:
+//   - oldDoc: The previously scraped document.
+//   - newDoc: The newly scraped document.
+//
+// Returns:
+//   - *DocumentDiff: The lines added to and removed from the old document, and their similarity.
+//   - error: An error if either document is nil.
+func DiffDocuments(oldDoc, newDoc *FirecrawlDocument) (*DocumentDiff, error) {
+	if oldDoc == nil || newDoc == nil {
+		return nil, fmt.Errorf("cannot diff a nil document")
+	}
+
+	oldLines := markdownLines(oldDoc.Markdown)
+	newLines := markdownLines(newDoc.Markdown)
+
+	diff := &DocumentDiff{Similarity: 1}
+	common := diffLines(oldLines, newLines, diff)
+	if total := len(oldLines) + len(newLines); total > 0 {
+		diff.Similarity = float64(2*common) / float64(total)
+	}
+	return diff, nil
+}
+
+// diffLines adds the lines removed from a and added in b to diff, in order, and returns the length
+// of their longest common subsequence. It uses Hirschberg's algorithm, so memory stays linear in
+// the number of lines.
+//
+// Parameters:
+//   - a: The old lines.
+//   - b: The new lines.
+//   - diff: The diff to add the removed and added lines to.
+//
+// Returns:
+//   - int: The number of lines a and b have in common.
+func diffLines(a, b []string, diff *DocumentDiff) int {
+	common := 0
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+		common++
+	}
+	suffix := 0
+	for len(a) > suffix && len(b) > suffix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+	common += suffix
+
+	switch {
+	case len(a) == 0 || len(b) == 0:
+		diff.Removed = append(diff.Removed, a...)
+		diff.Added = append(diff.Added, b...)
+		return common
+	case len(a) == 1:
+		for j, line := range b {
+			if line == a[0] {
+				diff.Added = append(diff.Added, b[:j]...)
+				diff.Added = append(diff.Added, b[j+1:]...)
+				return common + 1
+			}
+		}
+		diff.Removed = append(diff.Removed, a[0])
+		diff.Added = append(diff.Added, b...)
+		return common
+	}
+
+	// Split b where the longest common subsequences of the two halves of a with it add up to the
+	// longest, and diff each half separately.
+	mid := len(a) / 2
+	forward := lcsPrefixLengths(a[:mid], b)
+	backward := lcsSuffixLengths(a[mid:], b)
+	split := 0
+	for j := range forward {
+		if forward[j]+backward[j] > forward[split]+backward[split] {
+			split = j
+		}
+	}
+	return common + diffLines(a[:mid], b[:split], diff) + diffLines(a[mid:], b[split:], diff)
+}
+
+// lcsPrefixLengths returns, for each j, the length of the longest common subsequence of a and b[:j].
+func lcsPrefixLengths(a, b []string) []int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for _, line := range a {
+		for j := 1; j <= len(b); j++ {
+			if line == b[j-1] {
+				cur[j] = prev[j-1] + 1
+			} else {
+				cur[j] = max(prev[j], cur[j-1])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
+
+// lcsSuffixLengths returns, for each j, the length of the longest common subsequence of a and b[j:].
+func lcsSuffixLengths(a, b []string) []int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				cur[j] = prev[j+1] + 1
+			} else {
+				cur[j] = max(prev[j], cur[j+1])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
+
+// markdownLines returns the non-blank lines of the normalized markdown.
+func markdownLines(markdown string) []string {
+	var lines []string
+	for _, line := range strings.Split(CollapseWhitespace(markdown), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,316 @@
+package firecrawl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	nethtml "golang.org/x/net/html"
+)
+
+func TestParseHTML(t *testing.T) {
+	doc := &FirecrawlDocument{HTML: "<h1>Hello</h1>"}
+
+	root, err := doc.ParseHTML()
+	require.NoError(t, err)
+	var headings []string
+	var walk func(*nethtml.Node)
+	walk = func(n *nethtml.Node) {
+		if n.Type == nethtml.ElementNode && n.Data == "h1" && n.FirstChild != nil {
+			headings = append(headings, n.FirstChild.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(root)
+	assert.Equal(t, []string{"Hello"}, headings)
+
+	_, err = (&FirecrawlDocument{Markdown: "# Hello"}).ParseHTML()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "request the \"html\" format")
+}
+
+func TestMetadataRedirected(t *testing.T) {
+	var doc FirecrawlDocument
+	require.NoError(t, json.Unmarshal([]byte(`{"metadata":{"sourceURL":"http://example.com","url":"https://www.example.com/"}}`), &doc))
+
+	assert.Equal(t, "http://example.com", *doc.Metadata.SourceURL)
+	assert.Equal(t, "https://www.example.com/", *doc.Metadata.ResolvedURL)
+	assert.True(t, doc.Metadata.Redirected())
+
+	doc.Metadata.ResolvedURL = ptr("http://example.com")
+	assert.False(t, doc.Metadata.Redirected())
+
+	var metadata *FirecrawlDocumentMetadata
+	assert.False(t, metadata.Redirected())
+}
+
+func TestDocumentFormatPresenceRoundTrip(t *testing.T) {
+	var doc FirecrawlDocument
+	require.NoError(t, json.Unmarshal([]byte(`{"markdown":"# Hello","html":"","links":[]}`), &doc))
+
+	assert.True(t, doc.HasFormat("markdown"))
+	assert.True(t, doc.HasFormat("html"))
+	assert.True(t, doc.HasFormat("links"))
+	assert.False(t, doc.HasFormat("rawHtml"))
+	assert.False(t, doc.HasFormat("screenshot"))
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"markdown":"# Hello","html":"","links":[]}`, string(data))
+
+	var decoded FirecrawlDocument
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, decoded.HasFormat("html"))
+	assert.False(t, decoded.HasFormat("rawHtml"))
+}
+
+func TestDocumentMarshalWithoutPresence(t *testing.T) {
+	data, err := json.Marshal(&FirecrawlDocument{Markdown: "# Hello"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"markdown":"# Hello"}`, string(data))
+}
+
+func TestIframeURLs(t *testing.T) {
+	doc := &FirecrawlDocument{
+		RawHTML: `<html><body>
+			<iframe src="/embed/report?id=1" width="600"></iframe>
+			<IFRAME title='map' SRC='https://maps.example.org/view'></IFRAME>
+			<iframe src=widget.html></iframe>
+			<iframe src="about:blank"></iframe>
+			<iframe src="/embed/report?id=1"></iframe>
+			<script>document.write('<iframe src="/ads/frame"></iframe>');</script>
+			<!-- <iframe src="/old/embed"></iframe> -->
+		</body></html>`,
+		Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/pages/index.html")},
+	}
+
+	assert.Equal(t, []string{
+		"https://example.com/embed/report?id=1",
+		"https://maps.example.org/view",
+		"https://example.com/pages/widget.html",
+	}, doc.IframeURLs())
+
+	assert.Empty(t, (&FirecrawlDocument{Markdown: "# Hello"}).IframeURLs())
+}
+
+func TestDiffDocuments(t *testing.T) {
+	oldDoc := &FirecrawlDocument{Markdown: "# Title\n\nPrice: $10\n\nIn stock"}
+	newDoc := &FirecrawlDocument{Markdown: "# Title  \n\n\n\nPrice: $12\n\nIn stock\n\nFree shipping"}
+
+	diff, err := DiffDocuments(oldDoc, newDoc)
+	require.NoError(t, err)
+
+	assert.True(t, diff.Changed())
+	assert.Equal(t, []string{"Price: $10"}, diff.Removed)
+	assert.Equal(t, []string{"Price: $12", "Free shipping"}, diff.Added)
+	assert.InDelta(t, 4.0/7.0, diff.Similarity, 1e-9)
+}
+
+func TestDiffDocumentsLarge(t *testing.T) {
+	var oldLines, newLines, removed, added []string
+	for i := 0; i < 3000; i++ {
+		line := fmt.Sprintf("Line %d", i)
+		oldLines = append(oldLines, line)
+		if i%100 == 50 {
+			removed = append(removed, line)
+			continue
+		}
+		newLines = append(newLines, line)
+		if i%100 == 0 {
+			extra := fmt.Sprintf("Inserted after line %d", i)
+			newLines = append(newLines, extra)
+			added = append(added, extra)
+		}
+	}
+
+	diff, err := DiffDocuments(
+		&FirecrawlDocument{Markdown: strings.Join(oldLines, "\n")},
+		&FirecrawlDocument{Markdown: strings.Join(newLines, "\n")},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, removed, diff.Removed)
+	assert.Equal(t, added, diff.Added)
+	assert.InDelta(t, float64(2*(3000-30))/float64(6000), diff.Similarity, 1e-9)
+}
+
+func TestDiffDocumentsWhitespaceOnly(t *testing.T) {
+	diff, err := DiffDocuments(
+		&FirecrawlDocument{Markdown: "# Title\nBody"},
+		&FirecrawlDocument{Markdown: "# Title \r\n\n\nBody\t"},
+	)
+	require.NoError(t, err)
+
+	assert.False(t, diff.Changed())
+	assert.Equal(t, 1.0, diff.Similarity)
+}
+
+func TestDiffDocumentsNil(t *testing.T) {
+	_, err := DiffDocuments(nil, &FirecrawlDocument{})
+	assert.Error(t, err)
+}
+
+func TestDocumentTables(t *testing.T) {
+	doc := &FirecrawlDocument{Markdown: "| a | b |\n| - | - |\n| 1 | 2 |"}
+
+	assert.Equal(t, []MarkdownTable{{Header: []string{"a", "b"}, Rows: [][]string{{"1", "2"}}}}, doc.Tables())
+}
+
+func TestDocumentPlainText(t *testing.T) {
+	doc := &FirecrawlDocument{Markdown: "## Title\n\nSome **bold** [link](https://example.com)."}
+
+	assert.Equal(t, "Title\n\nSome bold link.", doc.PlainText())
+	assert.Empty(t, (*FirecrawlDocument)(nil).PlainText())
+}
+
+func TestDocumentEstimateTokens(t *testing.T) {
+	assert.Equal(t, 4, (&FirecrawlDocument{Markdown: "# Hello world"}).EstimateTokens())
+	assert.Equal(t, 6, (&FirecrawlDocument{Markdown: "東京タワー: ok"}).EstimateTokens())
+	assert.Zero(t, (&FirecrawlDocument{}).EstimateTokens())
+	assert.Zero(t, (*FirecrawlDocument)(nil).EstimateTokens())
+}
+
+func TestCrawlStatusResponseDeadLinks(t *testing.T) {
+	response := &CrawlStatusResponse{Data: []*FirecrawlDocument{
+		{Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/"), StatusCode: ptr(200)}},
+		{Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/missing"), StatusCode: ptr(404)}},
+		{Metadata: &FirecrawlDocumentMetadata{ResolvedURL: ptr("https://example.com/broken"), StatusCode: ptr(500)}},
+		{Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/unknown")}},
+		nil,
+	}}
+
+	assert.Equal(t, []string{"https://example.com/missing", "https://example.com/broken"}, response.DeadLinks())
+}
+
+func TestBatchScrapeStatusResponseDocumentsFor(t *testing.T) {
+	response := &BatchScrapeStatusResponse{Data: []*FirecrawlDocument{
+		{Markdown: "two", Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/2")}},
+		{Markdown: "one", Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/1")}},
+		{Markdown: "one again", Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/1")}},
+	}}
+
+	documents := response.DocumentsFor([]string{
+		"https://example.com/1",
+		"https://example.com/failed",
+		"https://example.com/2",
+		"https://example.com/1",
+	})
+
+	require.Len(t, documents, 4)
+	assert.Equal(t, "one", documents[0].Markdown)
+	assert.Nil(t, documents[1])
+	assert.Equal(t, "two", documents[2].Markdown)
+	assert.Equal(t, "one again", documents[3].Markdown)
+}
+
+func TestDocumentCanonicalURL(t *testing.T) {
+	doc := &FirecrawlDocument{
+		RawHTML: `<html><head><link rel="stylesheet" href="/style.css">` +
+			`<link href='/products/shoe' REL="Canonical"></head></html>`,
+		Metadata: &FirecrawlDocumentMetadata{
+			SourceURL:   ptr("https://example.com/products/shoe?color=red"),
+			ResolvedURL: ptr("https://www.example.com/products/shoe?color=red"),
+		},
+	}
+
+	canonical, ok := doc.CanonicalURL()
+	assert.True(t, ok)
+	assert.Equal(t, "https://www.example.com/products/shoe", canonical)
+
+	_, ok = (&FirecrawlDocument{HTML: `<link rel="stylesheet" href="/style.css">`}).CanonicalURL()
+	assert.False(t, ok)
+}
+
+func TestDocumentCanonicalURLUnescapesAttributes(t *testing.T) {
+	doc := &FirecrawlDocument{
+		RawHTML:  `<link rel="canonical" href="/search?q=shoes&amp;page=2">`,
+		Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/search")},
+	}
+
+	canonical, ok := doc.CanonicalURL()
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com/search?q=shoes&page=2", canonical)
+
+	doc.RawHTML = `<iframe src="/embed?id=1&amp;theme=dark"></iframe>`
+	assert.Equal(t, []string{"https://example.com/embed?id=1&theme=dark"}, doc.IframeURLs())
+}
+
+func TestDocumentCanonicalURLTokenizesHTML(t *testing.T) {
+	doc := &FirecrawlDocument{
+		RawHTML: `<head><!-- <link rel="canonical" href="/old"> -->` +
+			`<link title="x>y" rel="canonical" href="/new"></head>`,
+		Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/page")},
+	}
+
+	canonical, ok := doc.CanonicalURL()
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com/new", canonical)
+}
+
+func TestDocumentAlternateURLs(t *testing.T) {
+	doc := &FirecrawlDocument{
+		RawHTML: `<head><link rel="canonical" href="/en/pricing">` +
+			`<link rel="alternate" hreflang="de" href="/de/preise">` +
+			`<link hreflang='en-GB' rel='alternate' href='https://example.co.uk/pricing'>` +
+			`<link rel="alternate" hreflang="x-default" href="/pricing">` +
+			`<link rel="alternate" hreflang="de" href="/de/other">` +
+			`<link rel="alternate" type="application/rss+xml" href="/feed.xml">` +
+			`<!-- <link rel="alternate" hreflang="fr" href="/fr/tarifs"> -->` +
+			`<link title="Pricing > Español" rel="alternate" hreflang="es" href="/es/precios"></head>`,
+		Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/en/pricing")},
+	}
+
+	assert.Equal(t, map[string]string{
+		"de":        "https://example.com/de/preise",
+		"en-GB":     "https://example.co.uk/pricing",
+		"es":        "https://example.com/es/precios",
+		"x-default": "https://example.com/pricing",
+	}, doc.AlternateURLs())
+
+	assert.Nil(t, (&FirecrawlDocument{RawHTML: `<link rel="canonical" href="/">`}).AlternateURLs())
+}
+
+func TestDocumentAuthors(t *testing.T) {
+	doc := &FirecrawlDocument{
+		HTML: `<head><meta name="Author" content="Jane Doe &amp; Co">` +
+			`<meta property="og:title" content="Title">` +
+			`<script type="application/ld+json">{"@context":"https://schema.org","@graph":[` +
+			`{"@type":"Article","author":[{"@type":"Person","name":"John Roe"},{"@type":"Person","name":"Jane Doe &amp; Co"}]},` +
+			`{"@type":"WebPage","author":"Site Team"}]}</script>` +
+			`<script type="application/ld+json">not json</script>` +
+			`<!-- <meta name="author" content="Old Author"> -->` +
+			`<meta data-note="a>b" name="author" content="Fish &amp;amp; Chips"></head>`,
+	}
+
+	assert.Equal(t, []string{"Jane Doe & Co", "Fish &amp; Chips", "John Roe", "Site Team"}, doc.Authors())
+	assert.Nil(t, (&FirecrawlDocument{HTML: `<meta name="description" content="x">`}).Authors())
+}
+
+func TestDocumentJSONLD(t *testing.T) {
+	doc := &FirecrawlDocument{
+		RawHTML: `<script type="application/ld+json">
+			{"@context":"https://schema.org","@type":"Product","name":"Shoe","offers":{"price":"49.99"}}
+		</script><script>var x = 1;</script>` +
+			`<script type='application/ld+json'>{broken</script>` +
+			`<script type="application/ld+json">[{"@type":"BreadcrumbList"}]</script>`,
+	}
+
+	blocks := doc.JSONLD()
+	require.Len(t, blocks, 2)
+	assert.JSONEq(t, `{"@context":"https://schema.org","@type":"Product","name":"Shoe","offers":{"price":"49.99"}}`, string(blocks[0]))
+	assert.JSONEq(t, `[{"@type":"BreadcrumbList"}]`, string(blocks[1]))
+
+	assert.Nil(t, (&FirecrawlDocument{HTML: "<p>No data</p>"}).JSONLD())
+
+	doc.RawHTML = `<script data-type="application/ld+json">{"@type":"Thing"}</script>` +
+		`<!-- <script type="application/ld+json">{"@type":"Draft"}</script> -->` +
+		`<script TYPE=" Application/LD+JSON ">{"@type":"Article"}</script>`
+	blocks = doc.JSONLD()
+	require.Len(t, blocks, 1)
+	assert.JSONEq(t, `{"@type":"Article"}`, string(blocks[0]))
+}
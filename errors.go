@@ -0,0 +1,112 @@
+package firecrawl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// FirecrawlErrorDetail represents one field-level validation error reported by the Firecrawl API.
+type FirecrawlErrorDetail struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// String returns the detail as "path: message", e.g. "scrapeOptions.formats.0: Invalid enum value".
+func (d FirecrawlErrorDetail) String() string {
+	if len(d.Path) == 0 {
+		return d.Message
+	}
+	path := make([]string, len(d.Path))
+	for i, element := range d.Path {
+		path[i] = fmt.Sprint(element)
+	}
+	return fmt.Sprintf("%s: %s", strings.Join(path, "."), d.Message)
+}
+
+// FirecrawlError represents an error response returned by the Firecrawl API.
+// RequestBody holds the JSON body of the rejected request when WithDebugRequestBodies is enabled.
+type FirecrawlError struct {
+	StatusCode  int
+	Action      string
+	Message     string
+	Details     []FirecrawlErrorDetail
+	RequestBody []byte
+}
+
+// Error returns the error message.
+func (e *FirecrawlError) Error() string {
+	return e.Message
+}
+
+// RejectedFormats returns the formats of a scrape, crawl or batch scrape request that the API
+// rejected as invalid, for clients that request formats not every API version supports, such as
+// "summary" or "changeTracking", and retry without them. The API has no endpoint listing the formats
+// it supports, so they can only be found this way.
+//
+// Parameters:
+//   - err: The error returned for the request.
+//   - formats: The formats sent with the request, in order.
+//
+// Returns:
+//   - []string: The rejected formats, or nil if err is not a validation error about formats.
+func RejectedFormats(err error, formats []string) []string {
+	var firecrawlErr *FirecrawlError
+	if !errors.As(err, &firecrawlErr) || firecrawlErr.StatusCode != http.StatusBadRequest {
+		return nil
+	}
+
+	var rejected []string
+	for _, detail := range firecrawlErr.Details {
+		n := len(detail.Path)
+		if n < 2 || detail.Path[n-2] != "formats" {
+			continue
+		}
+		index, ok := detail.Path[n-1].(float64)
+		if !ok || index < 0 || int(index) >= len(formats) || index != float64(int(index)) {
+			continue
+		}
+		if format := formats[int(index)]; !slices.Contains(rejected, format) {
+			rejected = append(rejected, format)
+		}
+	}
+	return rejected
+}
+
+// StatusPageError reports a page of a completed job's status that could not be fetched or decoded.
+// It is returned together with the documents received before the failed page; see ResumeStatusPages.
+type StatusPageError struct {
+	URL string
+	Err error
+}
+
+// Error returns the error message.
+func (e *StatusPageError) Error() string {
+	return fmt.Sprintf("failed to fetch status page %s: %v", e.URL, e.Err)
+}
+
+// Unwrap returns the error that made the page fail.
+func (e *StatusPageError) Unwrap() error {
+	return e.Err
+}
+
+// parseErrorDetails parses the "details" field of an error response, if it has the expected shape.
+//
+// Parameters:
+//   - body: The response body of the error response.
+//
+// Returns:
+//   - []FirecrawlErrorDetail: The parsed details, or nil if there are none.
+func parseErrorDetails(body []byte) []FirecrawlErrorDetail {
+	var errorData struct {
+		Details []FirecrawlErrorDetail `json:"details"`
+	}
+	if err := json.Unmarshal(body, &errorData); err != nil {
+		return nil
+	}
+	return errorData.Details
+}
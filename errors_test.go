@@ -0,0 +1,95 @@
+package firecrawl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationErrorDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success":false,"error":"Bad Request","details":[{"code":"too_small","message":"Number must be greater than 0","path":["limit"]},{"code":"invalid_enum_value","message":"Invalid enum value","path":["scrapeOptions","formats",0]}]}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	_, err = app.AsyncCrawlURL("https://example.com", &CrawlParams{Limit: ptr(0)}, nil)
+	require.Error(t, err)
+	assert.Equal(t, "Bad Request: Failed to start crawl job. Bad Request (limit: Number must be greater than 0; scrapeOptions.formats.0: Invalid enum value)", err.Error())
+
+	var firecrawlErr *FirecrawlError
+	require.True(t, errors.As(err, &firecrawlErr))
+	assert.Equal(t, http.StatusBadRequest, firecrawlErr.StatusCode)
+	assert.Equal(t, "start crawl job", firecrawlErr.Action)
+	require.Len(t, firecrawlErr.Details, 2)
+	assert.Equal(t, "too_small", firecrawlErr.Details[0].Code)
+	assert.Equal(t, []any{"limit"}, firecrawlErr.Details[0].Path)
+}
+
+func TestErrorWithoutDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		fmt.Fprint(w, `{"success":false,"error":"Insufficient credits"}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	_, err = app.ScrapeURL("https://example.com", nil)
+	require.Error(t, err)
+	assert.Equal(t, "Payment Required: Failed to scrape URL. Insufficient credits", err.Error())
+
+	var firecrawlErr *FirecrawlError
+	require.True(t, errors.As(err, &firecrawlErr))
+	assert.Empty(t, firecrawlErr.Details)
+}
+
+func TestErrorRequestBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success":false,"error":"Bad Request"}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	_, err = app.ScrapeURL("https://example.com", &ScrapeParams{Formats: []string{"pdf"}})
+	var firecrawlErr *FirecrawlError
+	require.ErrorAs(t, err, &firecrawlErr)
+	assert.Nil(t, firecrawlErr.RequestBody)
+
+	app, err = NewFirecrawlApp("fc-test", server.URL, WithDebugRequestBodies(true))
+	require.NoError(t, err)
+
+	_, err = app.ScrapeURL("https://example.com", &ScrapeParams{Formats: []string{"pdf"}})
+	require.ErrorAs(t, err, &firecrawlErr)
+	assert.JSONEq(t, `{"url":"https://example.com","formats":["pdf"]}`, string(firecrawlErr.RequestBody))
+}
+
+func TestRejectedFormats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"success":false,"error":"Bad Request","details":[{"code":"invalid_enum_value","message":"Invalid enum value","path":["formats",1]},{"code":"invalid_enum_value","message":"Invalid enum value","path":["formats",2]},{"code":"too_small","message":"Number must be greater than 0","path":["timeout"]}]}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	formats := []string{"markdown", "summary", "changeTracking"}
+	_, err = app.ScrapeURL("https://example.com", &ScrapeParams{Formats: formats})
+	assert.Equal(t, []string{"summary", "changeTracking"}, RejectedFormats(err, formats))
+
+	assert.Nil(t, RejectedFormats(errors.New("network error"), formats))
+	assert.Nil(t, RejectedFormats(&FirecrawlError{StatusCode: http.StatusBadRequest, Details: []FirecrawlErrorDetail{{Path: []any{"formats", float64(7)}}}}, formats))
+}
@@ -3,22 +3,40 @@ package firecrawl
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
+	"math/rand"
 	"net/http"
+	neturl "net/url"
 	"os"
+	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
-// FirecrawlDocumentMetadata represents metadata for a Firecrawl document
+// FirecrawlDocumentMetadata represents metadata for a Firecrawl document.
+// SourceURL is the URL that was requested and ResolvedURL is the final URL after redirects.
+// Favicon is the URL of the page's icon, taken from its <link rel="icon"> element.
+// Language is declared by the page itself (its lang attribute or meta tags); the API does not
+// detect the language of the content.
+// The API reports no server-side timing for a scrape; the end-to-end duration of each request is
+// available through a MetricsCollector set with WithMetrics.
+// The API does not return the page's HTTP response headers; StatusCode is the only part of the
+// page's response it reports.
 type FirecrawlDocumentMetadata struct {
 	Title             *string   `json:"title,omitempty"`
 	Description       *string   `json:"description,omitempty"`
 	Language          *string   `json:"language,omitempty"`
 	Keywords          *string   `json:"keywords,omitempty"`
 	Robots            *string   `json:"robots,omitempty"`
+	Favicon           *string   `json:"favicon,omitempty"`
 	OGTitle           *string   `json:"ogTitle,omitempty"`
 	OGDescription     *string   `json:"ogDescription,omitempty"`
 	OGURL             *string   `json:"ogUrl,omitempty"`
@@ -44,10 +62,53 @@ type FirecrawlDocumentMetadata struct {
 	ArticleTag        *string   `json:"articleTag,omitempty"`
 	ArticleSection    *string   `json:"articleSection,omitempty"`
 	SourceURL         *string   `json:"sourceURL,omitempty"`
+	ResolvedURL       *string   `json:"url,omitempty"`
 	StatusCode        *int      `json:"statusCode,omitempty"`
 	Error             *string   `json:"error,omitempty"`
 }
 
+// ActionScrapeResult represents the page content captured by a "scrape" action.
+type ActionScrapeResult struct {
+	URL  string `json:"url"`
+	HTML string `json:"html"`
+}
+
+// ActionJavascriptReturn represents the value returned by the script of an "executeJavascript"
+// action. Type is the JavaScript type of the value (e.g. "string", "number" or "object") and Value
+// the value decoded from JSON.
+type ActionJavascriptReturn struct {
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+
+	// raw is the value as received, so that it can be decoded again with json.Number when the
+	// app was created with WithUseNumber.
+	raw json.RawMessage
+}
+
+// UnmarshalJSON decodes a returned value and keeps its raw JSON.
+func (r *ActionJavascriptReturn) UnmarshalJSON(data []byte) error {
+	var decoded struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*r = ActionJavascriptReturn{Type: decoded.Type, raw: decoded.Value}
+	if len(decoded.Value) == 0 {
+		return nil
+	}
+	return json.Unmarshal(decoded.Value, &r.Value)
+}
+
+// ActionsResult represents the output of the actions performed on a page, in the order they ran.
+type ActionsResult struct {
+	Screenshots       []string                 `json:"screenshots,omitempty"`
+	Scrapes           []ActionScrapeResult     `json:"scrapes,omitempty"`
+	JavascriptReturns []ActionJavascriptReturn `json:"javascriptReturns,omitempty"`
+}
+
 // FirecrawlDocument represents a document in Firecrawl
 type FirecrawlDocument struct {
 	Markdown   string                     `json:"markdown,omitempty"`
@@ -55,10 +116,91 @@ type FirecrawlDocument struct {
 	RawHTML    string                     `json:"rawHtml,omitempty"`
 	Screenshot string                     `json:"screenshot,omitempty"`
 	Links      []string                   `json:"links,omitempty"`
+	Actions    *ActionsResult             `json:"actions,omitempty"`
 	Metadata   *FirecrawlDocumentMetadata `json:"metadata,omitempty"`
+
+	// presentFormats records which format fields were present in the decoded JSON.
+	presentFormats map[string]bool
+}
+
+// Action types supported by the Firecrawl API.
+const (
+	ActionTypeWait              = "wait"
+	ActionTypeScreenshot        = "screenshot"
+	ActionTypeScrape            = "scrape"
+	ActionTypeExecuteJavascript = "executeJavascript"
+)
+
+// Action represents a browser action performed on the page before it is scraped.
+// A "wait" action waits either for a fixed number of milliseconds or until the element
+// matching Selector appears. The API has no network-idle wait; waiting for an element
+// rendered once the page has loaded its data is the reliable alternative for heavy SPAs.
+// Each "screenshot" action adds a capture to the document's Actions.Screenshots, and each "scrape"
+// action adds the page's intermediate state to Actions.Scrapes, in order.
+// An "executeJavascript" action runs Script in the page; the value it returns is added to the
+// document's Actions.JavascriptReturns, in order.
+type Action struct {
+	Type         string  `json:"type"`
+	Milliseconds *int    `json:"milliseconds,omitempty"`
+	Selector     *string `json:"selector,omitempty"`
+	FullPage     *bool   `json:"fullPage,omitempty"`
+	Script       *string `json:"script,omitempty"`
+}
+
+// WaitStrategy represents how a scrape waits for the page before capturing it: either for a fixed
+// duration or until an element appears. Create one with WaitForDuration or WaitForSelector.
+// The API has no network-idle wait.
+type WaitStrategy struct {
+	milliseconds *int
+	selector     *string
+}
+
+// WaitForDuration returns a WaitStrategy that waits a fixed number of milliseconds.
+//
+// Parameters:
+//   - milliseconds: How long to wait.
+//
+// Returns:
+//   - *WaitStrategy: The wait strategy.
+func WaitForDuration(milliseconds int) *WaitStrategy {
+	return &WaitStrategy{milliseconds: &milliseconds}
+}
+
+// WaitForSelector returns a WaitStrategy that waits until the element matching a CSS selector appears.
+//
+// Parameters:
+//   - selector: The CSS selector of the element to wait for.
+//
+// Returns:
+//   - *WaitStrategy: The wait strategy.
+func WaitForSelector(selector string) *WaitStrategy {
+	return &WaitStrategy{selector: &selector}
+}
+
+// LocationParams represents the location settings for a scrape request.
+// Country is an ISO 3166-1 alpha-2 code (e.g. "DE") that selects both the country of the proxy the
+// page is fetched through and the emulated browser locale. Languages overrides the emulated languages.
+type LocationParams struct {
+	Country   *string  `json:"country,omitempty"`
+	Languages []string `json:"languages,omitempty"`
 }
 
 // ScrapeParams represents the parameters for a scrape request.
+// IncludeTags and ExcludeTags accept CSS selectors, not only tag names, e.g. "div.article",
+// "#main" or "a[rel=nofollow]".
+// The API has no option to cap the length of the returned content; OnlyMainContent, IncludeTags
+// and ExcludeTags are the way to reduce it server-side.
+// The "screenshot" format captures the visible part of the page and "screenshot@fullPage" the whole
+// page. The API takes screenshots at its own viewport size, which cannot be configured; images that
+// must have fixed dimensions have to be resized after decoding.
+// LLM extraction options (the "json" format's jsonOptions) are not modeled yet, so extraction
+// settings such as web search or example inputs and outputs cannot be set through ScrapeParams.
+// Wait sets how the page is waited for and takes precedence over WaitFor, which is ignored when
+// Wait is set. A selector wait is sent as a "wait" action that runs before any other action.
+// Timeout defaults to the API's 30 seconds, or to 45 seconds when a screenshot or an LLM extraction
+// ("json" or "extract" format) is requested, since they take longer.
+// FastMode trades accuracy for speed: the page is fetched with the fastest engine available and
+// without the slower fallbacks used for hard-to-render pages. Leave it unset for critical pages.
 type ScrapeParams struct {
 	Formats         []string           `json:"formats,omitempty"`
 	Headers         *map[string]string `json:"headers,omitempty"`
@@ -68,6 +210,10 @@ type ScrapeParams struct {
 	WaitFor         *int               `json:"waitFor,omitempty"`
 	ParsePDF        *bool              `json:"parsePDF,omitempty"`
 	Timeout         *int               `json:"timeout,omitempty"`
+	Actions         []Action           `json:"actions,omitempty"`
+	Location        *LocationParams    `json:"location,omitempty"`
+	FastMode        *bool              `json:"fastMode,omitempty"`
+	Wait            *WaitStrategy      `json:"-"`
 }
 
 // ScrapeResponse represents the response for scraping operations
@@ -77,16 +223,32 @@ type ScrapeResponse struct {
 }
 
 // CrawlParams represents the parameters for a crawl request.
+// A crawl always starts from a single URL; the API has no parameter for seeding it with an explicit
+// sitemap or URL list. Set IgnoreSitemap to stop the crawler from discovering URLs via the site's sitemap.
+// Every crawled page is scraped and billed; to collect a site's URLs without scraping them, use MapURL.
+// Limit caps the number of pages scraped, and therefore billed; once it is reached, further discovered
+// URLs are dropped. The API has no separate cap on discovery.
+// IncludePaths and ExcludePaths are regular expressions matched against each URL's path, or against
+// the full URL (including subdomain and query string) when RegexOnFullURL is set.
+// IncludeSubdomains follows links to subdomains of the start URL's domain, like MapParams.IncludeSubdomains
+// does for maps; the crawl API calls it "allowSubdomains". AllowExternalLinks follows links to any domain.
+// DeduplicateSimilarURLs skips URLs that differ from an already crawled one only in ways that
+// usually lead to the same content, such as "www." or a trailing index page; the API enables it by
+// default. IgnoreQueryParameters additionally treats URLs that differ only in their query string as one.
 type CrawlParams struct {
-	ScrapeOptions      ScrapeParams `json:"scrapeOptions"`
-	Webhook            *string      `json:"webhook,omitempty"`
-	Limit              *int         `json:"limit,omitempty"`
-	IncludePaths       []string     `json:"includePaths,omitempty"`
-	ExcludePaths       []string     `json:"excludePaths,omitempty"`
-	MaxDepth           *int         `json:"maxDepth,omitempty"`
-	AllowBackwardLinks *bool        `json:"allowBackwardLinks,omitempty"`
-	AllowExternalLinks *bool        `json:"allowExternalLinks,omitempty"`
-	IgnoreSitemap      *bool        `json:"ignoreSitemap,omitempty"`
+	ScrapeOptions          ScrapeParams `json:"scrapeOptions"`
+	Webhook                *string      `json:"webhook,omitempty"`
+	Limit                  *int         `json:"limit,omitempty"`
+	IncludePaths           []string     `json:"includePaths,omitempty"`
+	ExcludePaths           []string     `json:"excludePaths,omitempty"`
+	MaxDepth               *int         `json:"maxDepth,omitempty"`
+	AllowBackwardLinks     *bool        `json:"allowBackwardLinks,omitempty"`
+	AllowExternalLinks     *bool        `json:"allowExternalLinks,omitempty"`
+	IgnoreSitemap          *bool        `json:"ignoreSitemap,omitempty"`
+	IgnoreQueryParameters  *bool        `json:"ignoreQueryParameters,omitempty"`
+	RegexOnFullURL         *bool        `json:"regexOnFullURL,omitempty"`
+	IncludeSubdomains      *bool        `json:"allowSubdomains,omitempty"`
+	DeduplicateSimilarURLs *bool        `json:"deduplicateSimilarURLs,omitempty"`
 }
 
 // CrawlResponse represents the response for crawling operations
@@ -107,13 +269,58 @@ type CrawlStatusResponse struct {
 	Data        []*FirecrawlDocument `json:"data,omitempty"`
 }
 
+// CrawlError represents a page that failed to be scraped during a crawl.
+type CrawlError struct {
+	ID        string  `json:"id"`
+	Timestamp *string `json:"timestamp,omitempty"`
+	URL       string  `json:"url"`
+	Error     string  `json:"error"`
+}
+
+// CrawlErrorsResponse represents the response for checking the errors of a crawl job.
+// RobotsBlocked lists the URLs the crawler skipped because the site's robots.txt disallows them.
+type CrawlErrorsResponse struct {
+	Errors        []CrawlError `json:"errors"`
+	RobotsBlocked []string     `json:"robotsBlocked"`
+}
+
 // CancelCrawlJobResponse represents the response for canceling a crawl job
 type CancelCrawlJobResponse struct {
 	Success bool   `json:"success"`
 	Status  string `json:"status"`
 }
 
+// BatchScrapeResponse represents the response for starting a batch scrape job
+type BatchScrapeResponse struct {
+	Success     bool     `json:"success"`
+	ID          string   `json:"id,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	InvalidURLs []string `json:"invalidURLs,omitempty"`
+}
+
+// BatchScrapeStatusResponse represents the response for checking a batch scrape job
+type BatchScrapeStatusResponse struct {
+	Status      string               `json:"status"`
+	Total       int                  `json:"total,omitempty"`
+	Completed   int                  `json:"completed,omitempty"`
+	CreditsUsed int                  `json:"creditsUsed,omitempty"`
+	ExpiresAt   string               `json:"expiresAt,omitempty"`
+	Next        *string              `json:"next,omitempty"`
+	Data        []*FirecrawlDocument `json:"data,omitempty"`
+}
+
+// StatusParams represents the pagination parameters for a crawl or batch scrape status request.
+// Skip is the number of documents to skip and Limit the maximum number of documents in the page.
+type StatusParams struct {
+	Skip  *int `json:"skip,omitempty"`
+	Limit *int `json:"limit,omitempty"`
+}
+
 // MapParams represents the parameters for a map request.
+// IncludeSubdomains includes URLs on subdomains of the given URL's domain, like CrawlParams.IncludeSubdomains.
+// When Search is set, the returned links are ordered from most to least relevant to the query,
+// and Limit keeps the most relevant ones. The API does not return the relevance scores themselves.
+// The API cannot restrict a map to a path; use MapResponse.FilterLinks to keep only matching links.
 type MapParams struct {
 	IncludeSubdomains *bool   `json:"includeSubdomains,omitempty"`
 	Search            *string `json:"search,omitempty"`
@@ -121,17 +328,37 @@ type MapParams struct {
 	Limit             *int    `json:"limit,omitempty"`
 }
 
-// MapResponse represents the response for mapping operations
+// MapResponse represents the response for mapping operations.
+// Links are ordered by relevance when the request sets MapParams.Search.
+// Links carry no sitemap metadata such as lastmod; use FetchSitemap to read it from the sitemap itself.
+// The API does not report whether a link came from the sitemap or was discovered on the site.
 type MapResponse struct {
 	Success bool     `json:"success"`
 	Links   []string `json:"links,omitempty"`
 	Error   string   `json:"error,omitempty"`
 }
 
+// UsagePeriod represents the credits used during one billing period
+type UsagePeriod struct {
+	StartDate   time.Time `json:"startDate"`
+	EndDate     time.Time `json:"endDate"`
+	APIKey      *string   `json:"apiKey,omitempty"`
+	CreditsUsed int       `json:"creditsUsed"`
+}
+
+// UsageHistoryResponse represents the response for the historical credit usage of a team
+type UsageHistoryResponse struct {
+	Success bool          `json:"success"`
+	Periods []UsagePeriod `json:"periods"`
+}
+
 // requestOptions represents options for making requests.
 type requestOptions struct {
-	retries int
-	backoff int
+	ctx      context.Context
+	retries  int
+	backoff  int
+	jitter   bool
+	response **http.Response
 }
 
 // requestOption is a functional option type for requestOptions.
@@ -145,7 +372,7 @@ type requestOption func(*requestOptions)
 // Returns:
 //   - *requestOptions: A new instance of requestOptions with the provided options.
 func newRequestOptions(opts ...requestOption) *requestOptions {
-	options := &requestOptions{retries: 1}
+	options := &requestOptions{ctx: context.Background(), retries: 1}
 	for _, opt := range opts {
 		opt(options)
 	}
@@ -178,12 +405,76 @@ func withBackoff(backoff int) requestOption {
 	}
 }
 
+// withContext sets the context for a request.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request and any wait before it is sent.
+//
+// Returns:
+//   - requestOption: A functional option that sets the context for a request.
+func withContext(ctx context.Context) requestOption {
+	return func(opts *requestOptions) {
+		opts.ctx = ctx
+	}
+}
+
+// withJitter enables jitter on the backoff interval of a request.
+//
+// Parameters:
+//   - jitter: Whether to randomize each backoff interval between half and all of its computed value.
+//
+// Returns:
+//   - requestOption: A functional option that enables jitter for a request.
+func withJitter(jitter bool) requestOption {
+	return func(opts *requestOptions) {
+		opts.jitter = jitter
+	}
+}
+
+// withResponse stores the HTTP response of the last attempt of a request, with its body already read.
+//
+// Parameters:
+//   - response: Where to store the HTTP response.
+//
+// Returns:
+//   - requestOption: A functional option that captures the HTTP response of a request.
+func withResponse(response **http.Response) requestOption {
+	return func(opts *requestOptions) {
+		opts.response = response
+	}
+}
+
 // FirecrawlApp represents a client for the Firecrawl API.
+// A FirecrawlApp is safe for concurrent use by multiple goroutines. Its exported fields must not be
+// modified once it is in use; to replace the HTTP client at runtime, for example to rotate
+// credentials held by a transport, use SetClient.
 type FirecrawlApp struct {
 	APIKey  string
 	APIURL  string
 	Client  *http.Client
 	Version string
+
+	maxResponseSize        int64
+	statusCheckRetries     int
+	statusCheckBackoff     int
+	statusCheckJitter      bool
+	maxBackoff             time.Duration
+	maxStatusPages         int
+	emptyResultRetries     *int
+	emptyResultDelay       time.Duration
+	maxJobWait             time.Duration
+	runningStatuses        map[string]bool
+	debugRequestBodies     bool
+	compressionThreshold   int
+	defaultOnlyMainContent *bool
+	rateLimiter            *rate.Limiter
+	metrics                MetricsCollector
+	useNumber              bool
+	origin                 string
+	accept                 string
+	retryPolicy            func(resp *http.Response, err error) bool
+	authScheme             *string
+	clientMu               sync.RWMutex
 }
 
 // NewFirecrawlApp creates a new instance of FirecrawlApp with the provided API key and API URL.
@@ -193,11 +484,12 @@ type FirecrawlApp struct {
 // Parameters:
 //   - apiKey: The API key for authenticating with the Firecrawl API. If empty, it will be retrieved from the FIRECRAWL_API_KEY environment variable.
 //   - apiURL: The base URL for the Firecrawl API. If empty, it will be retrieved from the FIRECRAWL_API_URL environment variable, defaulting to "https://api.firecrawl.dev".
+//   - opts: Optional app options.
 //
 // Returns:
 //   - *FirecrawlApp: A new instance of FirecrawlApp configured with the provided or retrieved API key and API URL.
 //   - error: An error if the API key is not provided or retrieved.
-func NewFirecrawlApp(apiKey, apiURL string) (*FirecrawlApp, error) {
+func NewFirecrawlApp(apiKey, apiURL string, opts ...AppOption) (*FirecrawlApp, error) {
 	if apiKey == "" {
 		apiKey = os.Getenv("FIRECRAWL_API_KEY")
 		if apiKey == "" {
@@ -216,14 +508,86 @@ func NewFirecrawlApp(apiKey, apiURL string) (*FirecrawlApp, error) {
 		Timeout: 60 * time.Second,
 	}
 
-	return &FirecrawlApp{
+	app := &FirecrawlApp{
 		APIKey: apiKey,
 		APIURL: apiURL,
 		Client: client,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(app)
+	}
+
+	return app, nil
+}
+
+// SetClient replaces the HTTP client used to make requests to the Firecrawl API. It is safe to
+// call while other requests are in flight; requests already sent keep using the previous client.
+//
+// Parameters:
+//   - client: The HTTP client to use.
+func (app *FirecrawlApp) SetClient(client *http.Client) {
+	app.clientMu.Lock()
+	defer app.clientMu.Unlock()
+	app.Client = client
+}
+
+// CloneWithAPIKey returns a copy of the app that authenticates with a different API key, for
+// services that make requests on behalf of several Firecrawl accounts. The copy has the same
+// configuration and shares the HTTP client, and with it the connection pool, as well as the rate
+// limiter and metrics collector. Replacing the client of either app with SetClient afterwards does
+// not affect the other.
+//
+// Parameters:
+//   - apiKey: The API key used by the copy.
+//
+// Returns:
+//   - *FirecrawlApp: The copy of the app.
+func (app *FirecrawlApp) CloneWithAPIKey(apiKey string) *FirecrawlApp {
+	return &FirecrawlApp{
+		APIKey:  apiKey,
+		APIURL:  app.APIURL,
+		Client:  app.httpClient(),
+		Version: app.Version,
+
+		maxResponseSize:        app.maxResponseSize,
+		statusCheckRetries:     app.statusCheckRetries,
+		statusCheckBackoff:     app.statusCheckBackoff,
+		statusCheckJitter:      app.statusCheckJitter,
+		maxBackoff:             app.maxBackoff,
+		maxStatusPages:         app.maxStatusPages,
+		emptyResultRetries:     app.emptyResultRetries,
+		emptyResultDelay:       app.emptyResultDelay,
+		maxJobWait:             app.maxJobWait,
+		runningStatuses:        app.runningStatuses,
+		debugRequestBodies:     app.debugRequestBodies,
+		compressionThreshold:   app.compressionThreshold,
+		defaultOnlyMainContent: app.defaultOnlyMainContent,
+		rateLimiter:            app.rateLimiter,
+		metrics:                app.metrics,
+		useNumber:              app.useNumber,
+		origin:                 app.origin,
+		accept:                 app.accept,
+		retryPolicy:            app.retryPolicy,
+		authScheme:             app.authScheme,
+	}
+}
+
+// httpClient returns the HTTP client used to make requests to the Firecrawl API, or a client with
+// the default settings if none is set.
+//
+// Returns:
+//   - *http.Client: The current HTTP client.
+func (app *FirecrawlApp) httpClient() *http.Client {
+	app.clientMu.RLock()
+	defer app.clientMu.RUnlock()
+	if app.Client == nil {
+		return &http.Client{}
+	}
+	return app.Client
 }
 
 // ScrapeURL scrapes the content of the specified URL using the Firecrawl API.
+// The page is always fetched by Firecrawl; the API cannot convert HTML supplied by the caller.
 //
 // Parameters:
 //   - url: The URL to be scraped.
@@ -233,6 +597,54 @@ func NewFirecrawlApp(apiKey, apiURL string) (*FirecrawlApp, error) {
 //   - *FirecrawlDocument or *FirecrawlDocumentV0: The scraped document data depending on the API version.
 //   - error: An error if the scrape request fails.
 func (app *FirecrawlApp) ScrapeURL(url string, params *ScrapeParams) (*FirecrawlDocument, error) {
+	return app.ScrapeURLWithContext(context.Background(), url, params)
+}
+
+// ScrapeURLWithContext scrapes the content of the specified URL using the Firecrawl API,
+// canceling the request when the context is done.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//   - url: The URL to be scraped.
+//   - params: Optional parameters for the scrape request.
+//
+// Returns:
+//   - *FirecrawlDocument: The scraped document data.
+//   - error: An error if the scrape request fails.
+func (app *FirecrawlApp) ScrapeURLWithContext(ctx context.Context, url string, params *ScrapeParams) (*FirecrawlDocument, error) {
+	return app.scrapeURL(ctx, url, params)
+}
+
+// ScrapeURLRaw scrapes the content of the specified URL using the Firecrawl API and also returns the
+// HTTP response of the Firecrawl API, for debugging. The response's body has already been read; it
+// is replaced by an in-memory copy that can be read again.
+//
+// Parameters:
+//   - url: The URL to be scraped.
+//   - params: Optional parameters for the scrape request.
+//
+// Returns:
+//   - *FirecrawlDocument: The scraped document data.
+//   - *http.Response: The HTTP response of the Firecrawl API, also returned with an error if one was received.
+//   - error: An error if the scrape request fails.
+func (app *FirecrawlApp) ScrapeURLRaw(url string, params *ScrapeParams) (*FirecrawlDocument, *http.Response, error) {
+	var resp *http.Response
+	doc, err := app.scrapeURL(context.Background(), url, params, withResponse(&resp))
+	return doc, resp, err
+}
+
+// scrapeURL scrapes the content of the specified URL using the Firecrawl API.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//   - url: The URL to be scraped.
+//   - params: Optional parameters for the scrape request.
+//   - opts: Additional request options.
+//
+// Returns:
+//   - *FirecrawlDocument: The scraped document data.
+//   - error: An error if the scrape request fails.
+func (app *FirecrawlApp) scrapeURL(ctx context.Context, url string, params *ScrapeParams, opts ...requestOption) (*FirecrawlDocument, error) {
 	headers := app.prepareHeaders(nil)
 	scrapeBody := map[string]any{"url": url}
 
@@ -254,32 +666,8 @@ func (app *FirecrawlApp) ScrapeURL(url string, params *ScrapeParams) (*Firecrawl
 	// 	}
 	// }
 
-	if params != nil {
-		if params.Formats != nil {
-			scrapeBody["formats"] = params.Formats
-		}
-		if params.Headers != nil {
-			scrapeBody["headers"] = params.Headers
-		}
-		if params.IncludeTags != nil {
-			scrapeBody["includeTags"] = params.IncludeTags
-		}
-		if params.ExcludeTags != nil {
-			scrapeBody["excludeTags"] = params.ExcludeTags
-		}
-		if params.OnlyMainContent != nil {
-			scrapeBody["onlyMainContent"] = params.OnlyMainContent
-		}
-		if params.WaitFor != nil {
-			scrapeBody["waitFor"] = params.WaitFor
-		}
-		if params.ParsePDF != nil {
-			scrapeBody["parsePDF"] = params.ParsePDF
-		}
-		if params.Timeout != nil {
-			scrapeBody["timeout"] = params.Timeout
-		}
-	}
+	addScrapeParams(scrapeBody, params)
+	app.addScrapeDefaults(scrapeBody)
 
 	resp, err := app.makeRequest(
 		http.MethodPost,
@@ -287,13 +675,14 @@ func (app *FirecrawlApp) ScrapeURL(url string, params *ScrapeParams) (*Firecrawl
 		scrapeBody,
 		headers,
 		"scrape URL",
+		append([]requestOption{withContext(ctx)}, opts...)...,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	var scrapeResponse ScrapeResponse
-	err = json.Unmarshal(resp, &scrapeResponse)
+	err = app.unmarshalResponse(resp, &scrapeResponse)
 
 	if scrapeResponse.Success {
 		return scrapeResponse.Data, nil
@@ -306,6 +695,151 @@ func (app *FirecrawlApp) ScrapeURL(url string, params *ScrapeParams) (*Firecrawl
 	return nil, fmt.Errorf("failed to scrape URL")
 }
 
+// ScrapeURLs scrapes multiple URLs concurrently with individual ScrapeURL calls, using at most
+// concurrency requests at a time. Unlike BatchScrapeURLs it does not create a batch scrape job.
+// Results and errors are returned in the order of urls; for each URL either its document or its
+// error is set. URLs not yet scraped when the context is done fail with the context's error.
+//
+// Parameters:
+//   - ctx: The context used to cancel the scrapes.
+//   - urls: The URLs to scrape.
+//   - params: Optional parameters applied to every scrape.
+//   - concurrency: The maximum number of concurrent scrapes. Values below 1 are treated as 1.
+//
+// Returns:
+//   - []*FirecrawlDocument: The scraped documents, indexed like urls.
+//   - []error: The scrape errors, indexed like urls.
+func (app *FirecrawlApp) ScrapeURLs(ctx context.Context, urls []string, params *ScrapeParams, concurrency int) ([]*FirecrawlDocument, []error) {
+	concurrency = max(concurrency, 1)
+	documents := make([]*FirecrawlDocument, len(urls))
+	errs := make([]error, len(urls))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(urls)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				documents[i], errs[i] = app.ScrapeURLWithContext(ctx, urls[i], params)
+			}
+		}()
+	}
+
+	for i := range urls {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return documents, errs
+}
+
+// FetchOGImage downloads the Open Graph image of a scraped document with the app's HTTP client.
+// The image is fetched directly from the site hosting it, without the Firecrawl API key. A relative
+// image URL is resolved against the page URL.
+//
+// Parameters:
+//   - ctx: The context used to cancel the download.
+//   - doc: The scraped document whose Metadata.OGImage to download.
+//   - maxBytes: The maximum size of the image in bytes. Zero or a negative value disables the limit.
+//
+// Returns:
+//   - []byte: The image data.
+//   - string: The image's content type, e.g. "image/png".
+//   - error: An error if the document has no OG image, the download fails, the response is not an
+//     image or it exceeds maxBytes.
+func (app *FirecrawlApp) FetchOGImage(ctx context.Context, doc *FirecrawlDocument, maxBytes int64) ([]byte, string, error) {
+	if doc == nil || doc.Metadata == nil || doc.Metadata.OGImage == nil || *doc.Metadata.OGImage == "" {
+		return nil, "", fmt.Errorf("document has no OG image")
+	}
+	imageURL := doc.resolveURL(*doc.Metadata.OGImage)
+
+	return app.download(ctx, imageURL, "OG image", maxBytes, func(contentType string) error {
+		if !strings.HasPrefix(contentType, "image/") {
+			return fmt.Errorf("OG image %s is not an image: content type %q", imageURL, contentType)
+		}
+		return nil
+	})
+}
+
+// FetchSourceFile downloads the original file of a scraped document, such as a PDF, with the app's
+// HTTP client. The API returns only the content extracted from the file, so the file is downloaded
+// again, directly from the site hosting it and without the Firecrawl API key, from the document's
+// resolved URL. The site may serve a different version than the one that was scraped.
+//
+// Parameters:
+//   - ctx: The context used to cancel the download.
+//   - doc: The scraped document whose file to download.
+//   - maxBytes: The maximum size of the file in bytes. Zero or a negative value disables the limit.
+//
+// Returns:
+//   - []byte: The file data.
+//   - string: The file's content type, e.g. "application/pdf".
+//   - error: An error if the document has no URL, the download fails or the file exceeds maxBytes.
+func (app *FirecrawlApp) FetchSourceFile(ctx context.Context, doc *FirecrawlDocument, maxBytes int64) ([]byte, string, error) {
+	var base *neturl.URL
+	if doc != nil {
+		base = doc.baseURL()
+	}
+	if base == nil {
+		return nil, "", fmt.Errorf("document has no URL")
+	}
+	return app.download(ctx, base.String(), "source file", maxBytes, nil)
+}
+
+// download fetches a file from a site other than the Firecrawl API with the app's HTTP client.
+//
+// Parameters:
+//   - ctx: The context used to cancel the download.
+//   - fileURL: The URL of the file.
+//   - description: A description of the file used in errors (e.g., "OG image").
+//   - maxBytes: The maximum size of the file in bytes. Zero or a negative value disables the limit.
+//   - checkContentType: An optional function rejecting the response's content type before the file is read (can be nil).
+//
+// Returns:
+//   - []byte: The file data.
+//   - string: The file's content type.
+//   - error: An error if the download fails, the content type is rejected or the file exceeds maxBytes.
+func (app *FirecrawlApp) download(ctx context.Context, fileURL, description string, maxBytes int64, checkContentType func(string) error) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := app.httpClient().Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("failed to fetch %s %s: status code %d", description, fileURL, resp.StatusCode)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if checkContentType != nil {
+		if err := checkContentType(contentType); err != nil {
+			return nil, "", err
+		}
+	}
+
+	var body io.Reader = resp.Body
+	if maxBytes > 0 {
+		body = io.LimitReader(resp.Body, maxBytes+1)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, "", err
+	}
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return nil, "", fmt.Errorf("%s %s exceeds maximum size of %d bytes", description, fileURL, maxBytes)
+	}
+	return data, contentType, nil
+}
+
 // CrawlURL starts a crawl job for the specified URL using the Firecrawl API.
 //
 // Parameters:
@@ -325,10 +859,11 @@ func (app *FirecrawlApp) CrawlURL(url string, params *CrawlParams, idempotencyKe
 
 	headers := app.prepareHeaders(&key)
 	crawlBody := map[string]any{"url": url}
+	scrapeOptions := map[string]any{}
 
 	if params != nil {
 		if params.ScrapeOptions.Formats != nil {
-			crawlBody["scrapeOptions"] = params.ScrapeOptions
+			addScrapeParams(scrapeOptions, &params.ScrapeOptions)
 		}
 		if params.Webhook != nil {
 			crawlBody["webhook"] = params.Webhook
@@ -354,6 +889,22 @@ func (app *FirecrawlApp) CrawlURL(url string, params *CrawlParams, idempotencyKe
 		if params.IgnoreSitemap != nil {
 			crawlBody["ignoreSitemap"] = params.IgnoreSitemap
 		}
+		if params.IgnoreQueryParameters != nil {
+			crawlBody["ignoreQueryParameters"] = params.IgnoreQueryParameters
+		}
+		if params.RegexOnFullURL != nil {
+			crawlBody["regexOnFullURL"] = params.RegexOnFullURL
+		}
+		if params.IncludeSubdomains != nil {
+			crawlBody["allowSubdomains"] = params.IncludeSubdomains
+		}
+		if params.DeduplicateSimilarURLs != nil {
+			crawlBody["deduplicateSimilarURLs"] = params.DeduplicateSimilarURLs
+		}
+	}
+	app.addScrapeDefaults(scrapeOptions)
+	if len(scrapeOptions) > 0 {
+		crawlBody["scrapeOptions"] = scrapeOptions
 	}
 
 	actualPollInterval := 2
@@ -375,15 +926,61 @@ func (app *FirecrawlApp) CrawlURL(url string, params *CrawlParams, idempotencyKe
 	}
 
 	var crawlResponse CrawlResponse
-	err = json.Unmarshal(resp, &crawlResponse)
+	err = app.unmarshalResponse(resp, &crawlResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	return app.monitorJobStatus(crawlResponse.ID, headers, actualPollInterval)
+	return app.monitorJobStatus(
+		context.Background(),
+		fmt.Sprintf("%s/v1/crawl/%s", app.APIURL, crawlResponse.ID),
+		"crawl",
+		headers,
+		actualPollInterval,
+		nil,
+	)
 }
 
-// CrawlURL starts a crawl job for the specified URL using the Firecrawl API.
+// CrawlURLWithProgress starts a crawl job for the specified URL using the Firecrawl API and waits for it
+// to complete like CrawlURL, invoking onProgress with the job status after every poll.
+//
+// Parameters:
+//   - url: The URL to crawl.
+//   - params: Optional parameters for the crawl request.
+//   - idempotencyKey: An optional idempotency key to ensure the request is idempotent (can be nil).
+//   - onProgress: A callback invoked with each status received, e.g. to report Completed out of Total.
+//   - pollInterval: An optional interval (in seconds) at which to poll the job status. Default is 2 seconds.
+//
+// Returns:
+//   - *CrawlStatusResponse: The crawl result if the job is completed.
+//   - error: An error if the crawl request fails.
+func (app *FirecrawlApp) CrawlURLWithProgress(url string, params *CrawlParams, idempotencyKey *string, onProgress func(*CrawlStatusResponse), pollInterval ...int) (*CrawlStatusResponse, error) {
+	actualPollInterval := 2
+	if len(pollInterval) > 0 {
+		actualPollInterval = pollInterval[0]
+	}
+
+	crawlResponse, err := app.AsyncCrawlURL(url, params, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.monitorJobStatus(
+		context.Background(),
+		fmt.Sprintf("%s/v1/crawl/%s", app.APIURL, crawlResponse.ID),
+		"crawl",
+		app.prepareHeaders(nil),
+		actualPollInterval,
+		onProgress,
+	)
+}
+
+// AsyncCrawlURL starts a crawl job for the specified URL using the Firecrawl API without waiting for it to finish.
+// If params.Webhook is set, the API also delivers the job's events to that URL; polling with CheckCrawlStatus
+// is then optional and does not consume additional credits.
+// The API never replays the original response for a reused idempotency key: it rejects the request
+// with a *FirecrawlError whose StatusCode is 409 (Conflict). A successful response therefore always
+// means a new job was started.
 //
 // Parameters:
 //   - url: The URL to crawl.
@@ -401,10 +998,11 @@ func (app *FirecrawlApp) AsyncCrawlURL(url string, params *CrawlParams, idempote
 
 	headers := app.prepareHeaders(&key)
 	crawlBody := map[string]any{"url": url}
+	scrapeOptions := map[string]any{}
 
 	if params != nil {
 		if params.ScrapeOptions.Formats != nil {
-			crawlBody["scrapeOptions"] = params.ScrapeOptions
+			addScrapeParams(scrapeOptions, &params.ScrapeOptions)
 		}
 		if params.Webhook != nil {
 			crawlBody["webhook"] = params.Webhook
@@ -430,6 +1028,22 @@ func (app *FirecrawlApp) AsyncCrawlURL(url string, params *CrawlParams, idempote
 		if params.IgnoreSitemap != nil {
 			crawlBody["ignoreSitemap"] = params.IgnoreSitemap
 		}
+		if params.IgnoreQueryParameters != nil {
+			crawlBody["ignoreQueryParameters"] = params.IgnoreQueryParameters
+		}
+		if params.RegexOnFullURL != nil {
+			crawlBody["regexOnFullURL"] = params.RegexOnFullURL
+		}
+		if params.IncludeSubdomains != nil {
+			crawlBody["allowSubdomains"] = params.IncludeSubdomains
+		}
+		if params.DeduplicateSimilarURLs != nil {
+			crawlBody["deduplicateSimilarURLs"] = params.DeduplicateSimilarURLs
+		}
+	}
+	app.addScrapeDefaults(scrapeOptions)
+	if len(scrapeOptions) > 0 {
+		crawlBody["scrapeOptions"] = scrapeOptions
 	}
 
 	resp, err := app.makeRequest(
@@ -447,7 +1061,7 @@ func (app *FirecrawlApp) AsyncCrawlURL(url string, params *CrawlParams, idempote
 	}
 
 	var crawlResponse CrawlResponse
-	err = json.Unmarshal(resp, &crawlResponse)
+	err = app.unmarshalResponse(resp, &crawlResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -459,6 +1073,33 @@ func (app *FirecrawlApp) AsyncCrawlURL(url string, params *CrawlParams, idempote
 	return &crawlResponse, nil
 }
 
+// AsyncCrawlURLWithWebhook starts a crawl job whose results are delivered only to the given webhook.
+// The SDK never polls the job; feed the events received by your webhook handler to WaitForCrawlWebhook
+// to block until the crawl finishes.
+//
+// Parameters:
+//   - url: The URL to crawl.
+//   - webhook: The URL that receives the crawl job's webhook events.
+//   - params: Optional parameters for the crawl request. Its Webhook field is overridden.
+//   - idempotencyKey: An optional idempotency key to ensure the request is idempotent.
+//
+// Returns:
+//   - *CrawlResponse: The crawl response with id.
+//   - error: An error if the webhook is empty or the crawl request fails.
+func (app *FirecrawlApp) AsyncCrawlURLWithWebhook(url string, webhook string, params *CrawlParams, idempotencyKey *string) (*CrawlResponse, error) {
+	if webhook == "" {
+		return nil, fmt.Errorf("no webhook provided")
+	}
+
+	var webhookParams CrawlParams
+	if params != nil {
+		webhookParams = *params
+	}
+	webhookParams.Webhook = &webhook
+
+	return app.AsyncCrawlURL(url, &webhookParams, idempotencyKey)
+}
+
 // CheckCrawlStatus checks the status of a crawl job using the Firecrawl API.
 //
 // Parameters:
@@ -468,24 +1109,27 @@ func (app *FirecrawlApp) AsyncCrawlURL(url string, params *CrawlParams, idempote
 //   - *CrawlStatusResponse: The status of the crawl job.
 //   - error: An error if the crawl status check request fails.
 func (app *FirecrawlApp) CheckCrawlStatus(ID string) (*CrawlStatusResponse, error) {
-	headers := app.prepareHeaders(nil)
-	apiURL := fmt.Sprintf("%s/v1/crawl/%s", app.APIURL, ID)
+	return app.CheckCrawlStatusWithParams(ID, nil)
+}
 
-	resp, err := app.makeRequest(
-		http.MethodGet,
-		apiURL,
-		nil,
-		headers,
-		"check crawl status",
-		withRetries(3),
-		withBackoff(500),
-	)
+// CheckCrawlStatusWithParams checks the status of a crawl job using the Firecrawl API, with pagination
+// parameters controlling which page of documents is returned.
+//
+// Parameters:
+//   - ID: The ID of the crawl job to check.
+//   - params: Optional pagination parameters for the status request.
+//
+// Returns:
+//   - *CrawlStatusResponse: The status of the crawl job.
+//   - error: An error if the crawl status check request fails.
+func (app *FirecrawlApp) CheckCrawlStatusWithParams(ID string, params *StatusParams) (*CrawlStatusResponse, error) {
+	resp, err := app.checkCrawlStatusRaw(ID, params)
 	if err != nil {
 		return nil, err
 	}
 
 	var jobStatusResponse CrawlStatusResponse
-	err = json.Unmarshal(resp, &jobStatusResponse)
+	err = app.unmarshalResponse(resp, &jobStatusResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -493,15 +1137,85 @@ func (app *FirecrawlApp) CheckCrawlStatus(ID string) (*CrawlStatusResponse, erro
 	return &jobStatusResponse, nil
 }
 
+// CheckCrawlStatusRaw checks the status of a crawl job using the Firecrawl API and returns the
+// response body exactly as the server sent it, including fields CrawlStatusResponse does not model.
+//
+// Parameters:
+//   - ID: The ID of the crawl job to check.
+//
+// Returns:
+//   - []byte: The raw JSON response body.
+//   - error: An error if the crawl status check request fails.
+func (app *FirecrawlApp) CheckCrawlStatusRaw(ID string) ([]byte, error) {
+	return app.checkCrawlStatusRaw(ID, nil)
+}
+
+// checkCrawlStatusRaw checks the status of a crawl job using the Firecrawl API.
+//
+// Parameters:
+//   - ID: The ID of the crawl job to check.
+//   - params: Optional pagination parameters for the status request.
+//
+// Returns:
+//   - []byte: The raw JSON response body.
+//   - error: An error if the crawl status check request fails.
+func (app *FirecrawlApp) checkCrawlStatusRaw(ID string, params *StatusParams) ([]byte, error) {
+	headers := app.prepareHeaders(nil)
+	apiURL := fmt.Sprintf("%s/v1/crawl/%s%s", app.APIURL, ID, params.queryString())
+
+	return app.makeRequest(
+		http.MethodGet,
+		apiURL,
+		nil,
+		headers,
+		"check crawl status",
+		app.statusCheckOptions()...,
+	)
+}
+
+// CheckCrawlErrors returns the errors of a crawl job using the Firecrawl API: the pages that failed
+// to be scraped and the URLs that were skipped because of the site's robots.txt.
+//
+// Parameters:
+//   - ID: The ID of the crawl job to check.
+//
+// Returns:
+//   - *CrawlErrorsResponse: The errors of the crawl job.
+//   - error: An error if the crawl errors request fails.
+func (app *FirecrawlApp) CheckCrawlErrors(ID string) (*CrawlErrorsResponse, error) {
+	headers := app.prepareHeaders(nil)
+	apiURL := fmt.Sprintf("%s/v1/crawl/%s/errors", app.APIURL, ID)
+
+	resp, err := app.makeRequest(
+		http.MethodGet,
+		apiURL,
+		nil,
+		headers,
+		"check crawl errors",
+		app.statusCheckOptions()...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var crawlErrorsResponse CrawlErrorsResponse
+	err = app.unmarshalResponse(resp, &crawlErrorsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &crawlErrorsResponse, nil
+}
+
 // CancelCrawlJob cancels a crawl job using the Firecrawl API.
 //
 // Parameters:
 //   - ID: The ID of the crawl job to cancel.
 //
 // Returns:
-//   - string: The status of the crawl job after cancellation.
+//   - *CancelCrawlJobResponse: The cancellation response, including whether it succeeded.
 //   - error: An error if the crawl job cancellation request fails.
-func (app *FirecrawlApp) CancelCrawlJob(ID string) (string, error) {
+func (app *FirecrawlApp) CancelCrawlJob(ID string) (*CancelCrawlJobResponse, error) {
 	headers := app.prepareHeaders(nil)
 	apiURL := fmt.Sprintf("%s/v1/crawl/%s", app.APIURL, ID)
 	resp, err := app.makeRequest(
@@ -512,19 +1226,202 @@ func (app *FirecrawlApp) CancelCrawlJob(ID string) (string, error) {
 		"cancel crawl job",
 	)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var cancelCrawlJobResponse CancelCrawlJobResponse
-	err = json.Unmarshal(resp, &cancelCrawlJobResponse)
+	err = app.unmarshalResponse(resp, &cancelCrawlJobResponse)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
+	return &cancelCrawlJobResponse, nil
+}
+
+// CancelCrawlJobStatus cancels a crawl job using the Firecrawl API and returns only its status.
+//
+// Deprecated: Use CancelCrawlJob, whose response also reports whether the cancellation succeeded.
+//
+// Parameters:
+//   - ID: The ID of the crawl job to cancel.
+//
+// Returns:
+//   - string: The status of the crawl job after cancellation.
+//   - error: An error if the crawl job cancellation request fails.
+func (app *FirecrawlApp) CancelCrawlJobStatus(ID string) (string, error) {
+	cancelCrawlJobResponse, err := app.CancelCrawlJob(ID)
+	if err != nil {
+		return "", err
+	}
 	return cancelCrawlJobResponse.Status, nil
 }
 
+// BatchScrapeURLs scrapes multiple URLs in a single batch scrape job using the Firecrawl API
+// and waits for the job to complete.
+//
+// Parameters:
+//   - urls: The URLs to scrape.
+//   - params: Optional parameters applied to every scrape in the batch.
+//   - idempotencyKey: An optional idempotency key to ensure the request is idempotent (can be nil).
+//   - pollInterval: An optional interval (in seconds) at which to poll the job status. Default is 2 seconds.
+//
+// Returns:
+//   - *BatchScrapeStatusResponse: The batch scrape result if the job is completed.
+//   - error: An error if the batch scrape request fails.
+func (app *FirecrawlApp) BatchScrapeURLs(urls []string, params *ScrapeParams, idempotencyKey *string, pollInterval ...int) (*BatchScrapeStatusResponse, error) {
+	return app.BatchScrapeURLsWithContext(context.Background(), urls, params, idempotencyKey, pollInterval...)
+}
+
+// BatchScrapeURLsWithContext scrapes multiple URLs in a single batch scrape job using the Firecrawl API
+// and waits for the job to complete or the context to be done. If the context is done while the job is
+// running, the partial result from the last status check is returned along with the context's error.
+//
+// Parameters:
+//   - ctx: The context used to cancel the submission and stop polling.
+//   - urls: The URLs to scrape.
+//   - params: Optional parameters applied to every scrape in the batch.
+//   - idempotencyKey: An optional idempotency key to ensure the request is idempotent (can be nil).
+//   - pollInterval: An optional interval (in seconds) at which to poll the job status. Default is 2 seconds.
+//
+// Returns:
+//   - *BatchScrapeStatusResponse: The batch scrape result, or the partial result if the context is done.
+//   - error: An error if the batch scrape request fails or the context is done.
+func (app *FirecrawlApp) BatchScrapeURLsWithContext(ctx context.Context, urls []string, params *ScrapeParams, idempotencyKey *string, pollInterval ...int) (*BatchScrapeStatusResponse, error) {
+	actualPollInterval := 2
+	if len(pollInterval) > 0 {
+		actualPollInterval = pollInterval[0]
+	}
+
+	batchScrapeResponse, err := app.asyncBatchScrapeURLs(ctx, urls, params, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	statusData, err := app.monitorJobStatus(
+		ctx,
+		fmt.Sprintf("%s/v1/batch/scrape/%s", app.APIURL, batchScrapeResponse.ID),
+		"batch scrape",
+		app.prepareHeaders(nil),
+		actualPollInterval,
+		nil,
+	)
+	return (*BatchScrapeStatusResponse)(statusData), err
+}
+
+// AsyncBatchScrapeURLs starts a batch scrape job for the specified URLs using the Firecrawl API
+// without waiting for it to finish.
+//
+// Parameters:
+//   - urls: The URLs to scrape.
+//   - params: Optional parameters applied to every scrape in the batch.
+//   - idempotencyKey: An optional idempotency key to ensure the request is idempotent (can be nil).
+//
+// Returns:
+//   - *BatchScrapeResponse: The batch scrape response with id.
+//   - error: An error if the batch scrape request fails.
+func (app *FirecrawlApp) AsyncBatchScrapeURLs(urls []string, params *ScrapeParams, idempotencyKey *string) (*BatchScrapeResponse, error) {
+	return app.asyncBatchScrapeURLs(context.Background(), urls, params, idempotencyKey)
+}
+
+// asyncBatchScrapeURLs starts a batch scrape job for the specified URLs using the Firecrawl API.
+//
+// Parameters:
+//   - ctx: The context used to cancel the request.
+//   - urls: The URLs to scrape.
+//   - params: Optional parameters applied to every scrape in the batch.
+//   - idempotencyKey: An optional idempotency key to ensure the request is idempotent (can be nil).
+//
+// Returns:
+//   - *BatchScrapeResponse: The batch scrape response with id.
+//   - error: An error if the batch scrape request fails.
+func (app *FirecrawlApp) asyncBatchScrapeURLs(ctx context.Context, urls []string, params *ScrapeParams, idempotencyKey *string) (*BatchScrapeResponse, error) {
+	var key string
+	if idempotencyKey != nil {
+		key = *idempotencyKey
+	}
+
+	headers := app.prepareHeaders(&key)
+	batchScrapeBody := map[string]any{"urls": urls}
+	addScrapeParams(batchScrapeBody, params)
+	app.addScrapeDefaults(batchScrapeBody)
+
+	resp, err := app.makeRequest(
+		http.MethodPost,
+		fmt.Sprintf("%s/v1/batch/scrape", app.APIURL),
+		batchScrapeBody,
+		headers,
+		"start batch scrape job",
+		withRetries(3),
+		withBackoff(500),
+		withContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var batchScrapeResponse BatchScrapeResponse
+	err = app.unmarshalResponse(resp, &batchScrapeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchScrapeResponse.ID == "" {
+		return nil, fmt.Errorf("failed to get job ID")
+	}
+
+	return &batchScrapeResponse, nil
+}
+
+// CheckBatchScrapeStatus checks the status of a batch scrape job using the Firecrawl API.
+//
+// Parameters:
+//   - ID: The ID of the batch scrape job to check.
+//
+// Returns:
+//   - *BatchScrapeStatusResponse: The status of the batch scrape job.
+//   - error: An error if the batch scrape status check request fails.
+func (app *FirecrawlApp) CheckBatchScrapeStatus(ID string) (*BatchScrapeStatusResponse, error) {
+	return app.CheckBatchScrapeStatusWithParams(ID, nil)
+}
+
+// CheckBatchScrapeStatusWithParams checks the status of a batch scrape job using the Firecrawl API,
+// with pagination parameters controlling which page of documents is returned.
+//
+// Parameters:
+//   - ID: The ID of the batch scrape job to check.
+//   - params: Optional pagination parameters for the status request.
+//
+// Returns:
+//   - *BatchScrapeStatusResponse: The status of the batch scrape job.
+//   - error: An error if the batch scrape status check request fails.
+func (app *FirecrawlApp) CheckBatchScrapeStatusWithParams(ID string, params *StatusParams) (*BatchScrapeStatusResponse, error) {
+	headers := app.prepareHeaders(nil)
+	apiURL := fmt.Sprintf("%s/v1/batch/scrape/%s%s", app.APIURL, ID, params.queryString())
+
+	resp, err := app.makeRequest(
+		http.MethodGet,
+		apiURL,
+		nil,
+		headers,
+		"check batch scrape status",
+		app.statusCheckOptions()...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var batchScrapeStatusResponse BatchScrapeStatusResponse
+	err = app.unmarshalResponse(resp, &batchScrapeStatusResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchScrapeStatusResponse, nil
+}
+
 // MapURL initiates a mapping operation for a URL using the Firecrawl API.
+// Mapping discovers a site's URLs without scraping their content, which makes it the cheap
+// alternative to a crawl when only the links are needed.
 //
 // Parameters:
 //   - url: The URL to map.
@@ -564,7 +1461,7 @@ func (app *FirecrawlApp) MapURL(url string, params *MapParams) (*MapResponse, er
 	}
 
 	var mapResponse MapResponse
-	err = json.Unmarshal(resp, &mapResponse)
+	err = app.unmarshalResponse(resp, &mapResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -576,6 +1473,51 @@ func (app *FirecrawlApp) MapURL(url string, params *MapParams) (*MapResponse, er
 	}
 }
 
+// GetUsageHistory retrieves the team's historical credit usage using the Firecrawl API.
+// The API reports usage per billing period; only the periods overlapping the given time range are returned.
+//
+// Parameters:
+//   - from: The start of the time range.
+//   - to: The end of the time range.
+//
+// Returns:
+//   - *UsageHistoryResponse: The credit usage of each billing period overlapping the time range.
+//   - error: An error if the time range is invalid or the usage request fails.
+func (app *FirecrawlApp) GetUsageHistory(from, to time.Time) (*UsageHistoryResponse, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid time range: %s is before %s", to, from)
+	}
+
+	headers := app.prepareHeaders(nil)
+	resp, err := app.makeRequest(
+		http.MethodGet,
+		fmt.Sprintf("%s/v1/team/credit-usage/historical", app.APIURL),
+		nil,
+		headers,
+		"get usage history",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var usageHistoryResponse UsageHistoryResponse
+	err = app.unmarshalResponse(resp, &usageHistoryResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := usageHistoryResponse.Periods[:0]
+	for _, period := range usageHistoryResponse.Periods {
+		if period.EndDate.Before(from) || period.StartDate.After(to) {
+			continue
+		}
+		periods = append(periods, period)
+	}
+	usageHistoryResponse.Periods = periods
+
+	return &usageHistoryResponse, nil
+}
+
 // SearchURL searches for a URL using the Firecrawl API.
 //
 // Parameters:
@@ -583,11 +1525,101 @@ func (app *FirecrawlApp) MapURL(url string, params *MapParams) (*MapResponse, er
 //   - params: Optional parameters for the search request.
 //   - error: An error if the search request fails.
 //
-// Search is not implemented in API version 1.0.0.
+// Search is not implemented in API version 1.0.0, so none of its options, such as choosing
+// web, news or image result sources or targeting the results to a country and location, are
+// available yet. It has no context-aware variant because it returns without making a request.
 func (app *FirecrawlApp) Search(query string, params *any) (any, error) {
 	return nil, fmt.Errorf("Search is not implemented in API version 1.0.0")
 }
 
+// slowFormatTimeout is the scrape timeout (in milliseconds) sent when a slow format is requested
+// without a timeout, raised from the API's 30 second default while staying within the default
+// client's 60 second timeout.
+const slowFormatTimeout = 45000
+
+// hasSlowFormat reports whether formats include one that takes longer than the others to produce:
+// a screenshot or an LLM extraction.
+//
+// Parameters:
+//   - formats: The requested formats.
+//
+// Returns:
+//   - bool: True if a slow format is requested.
+func hasSlowFormat(formats []string) bool {
+	for _, format := range formats {
+		switch format {
+		case "screenshot", "screenshot@fullPage", "json", "extract":
+			return true
+		}
+	}
+	return false
+}
+
+// addScrapeParams adds the set fields of the scrape parameters to a request body.
+//
+// Parameters:
+//   - body: The request body to add the scrape parameters to.
+//   - params: Optional parameters for the scrape request.
+func addScrapeParams(body map[string]any, params *ScrapeParams) {
+	if params != nil {
+		if params.Formats != nil {
+			body["formats"] = params.Formats
+		}
+		if params.Headers != nil {
+			body["headers"] = params.Headers
+		}
+		if params.IncludeTags != nil {
+			body["includeTags"] = params.IncludeTags
+		}
+		if params.ExcludeTags != nil {
+			body["excludeTags"] = params.ExcludeTags
+		}
+		if params.OnlyMainContent != nil {
+			body["onlyMainContent"] = params.OnlyMainContent
+		}
+		if params.WaitFor != nil {
+			body["waitFor"] = params.WaitFor
+		}
+		if params.ParsePDF != nil {
+			body["parsePDF"] = params.ParsePDF
+		}
+		if params.Timeout != nil {
+			body["timeout"] = params.Timeout
+		} else if hasSlowFormat(params.Formats) {
+			body["timeout"] = slowFormatTimeout
+		}
+		if params.Actions != nil {
+			body["actions"] = params.Actions
+		}
+		if params.Location != nil {
+			body["location"] = params.Location
+		}
+		if params.FastMode != nil {
+			body["fastMode"] = params.FastMode
+		}
+		if params.Wait != nil {
+			delete(body, "waitFor")
+			if params.Wait.milliseconds != nil {
+				body["waitFor"] = params.Wait.milliseconds
+			}
+			if params.Wait.selector != nil {
+				body["actions"] = append([]Action{{Type: ActionTypeWait, Selector: params.Wait.selector}}, params.Actions...)
+			}
+		}
+	}
+}
+
+// addScrapeDefaults adds the app-level scrape defaults to a request body, for the options the
+// scrape parameters left unset.
+//
+// Parameters:
+//   - body: The request body to add the defaults to.
+func (app *FirecrawlApp) addScrapeDefaults(body map[string]any) {
+	if _, ok := body["onlyMainContent"]; !ok && app.defaultOnlyMainContent != nil {
+		body["onlyMainContent"] = app.defaultOnlyMainContent
+	}
+}
+
 // prepareHeaders prepares the headers for an HTTP request.
 //
 // Parameters:
@@ -597,13 +1629,30 @@ func (app *FirecrawlApp) Search(query string, params *any) (any, error) {
 // Returns:
 //   - map[string]string: A map containing the headers for the HTTP request.
 func (app *FirecrawlApp) prepareHeaders(idempotencyKey *string) map[string]string {
+	authorization := fmt.Sprintf("Bearer %s", app.APIKey)
+	if app.authScheme != nil {
+		authorization = app.APIKey
+		if *app.authScheme != "" {
+			authorization = fmt.Sprintf("%s %s", *app.authScheme, app.APIKey)
+		}
+	}
+
+	accept := "application/json"
+	if app.accept != "" {
+		accept = app.accept
+	}
+
 	headers := map[string]string{
 		"Content-Type":  "application/json",
-		"Authorization": fmt.Sprintf("Bearer %s", app.APIKey),
+		"Accept":        accept,
+		"Authorization": authorization,
 	}
 	if idempotencyKey != nil {
 		headers["x-idempotency-key"] = *idempotencyKey
 	}
+	if app.origin != "" {
+		headers["X-Origin"] = app.origin
+	}
 	return headers
 }
 
@@ -630,7 +1679,17 @@ func (app *FirecrawlApp) makeRequest(method, url string, data map[string]any, he
 		}
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	compress := app.compressionThreshold > 0 && len(body) >= app.compressionThreshold
+	requestBody := body
+	if compress {
+		requestBody, err = gzipBytes(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	options := newRequestOptions(opts...)
+	req, err := http.NewRequestWithContext(options.ctx, method, url, bytes.NewBuffer(requestBody))
 	if err != nil {
 		return nil, err
 	}
@@ -638,68 +1697,308 @@ func (app *FirecrawlApp) makeRequest(method, url string, data map[string]any, he
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
+	if compress {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	attempts := max(options.retries, 1)
+	retryPolicy := app.retryPolicy
+	if retryPolicy == nil {
+		retryPolicy = defaultRetryPolicy
+	}
 
 	var resp *http.Response
-	options := newRequestOptions(opts...)
-	for i := 0; i < options.retries; i++ {
-		resp, err = app.Client.Do(req)
-		if err != nil {
-			return nil, err
+	var respBody []byte
+	retryable := false
+	for i := 0; i < attempts; i++ {
+		if i > 0 && app.metrics != nil {
+			app.metrics.ObserveRetry(action)
+		}
+		if app.rateLimiter != nil {
+			if err := app.rateLimiter.Wait(options.ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		if i > 0 && req.GetBody != nil {
+			// The previous attempt consumed the body; send a fresh copy with the retry.
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		start := time.Now()
+		resp, err = app.httpClient().Do(req)
+		if app.metrics != nil {
+			statusCode := 0
+			if resp != nil {
+				statusCode = resp.StatusCode
+			}
+			app.metrics.ObserveRequest(action, statusCode, time.Since(start))
+		}
+		if err == nil {
+			// Read and close the body of every attempt so the connection can be reused
+			// and the retry policy can inspect it.
+			respBody, err = app.readResponseBody(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode != 502 {
+		retryable = retryPolicy(resp, err)
+		if !retryable || i == attempts-1 {
 			break
 		}
+		if options.ctx.Err() != nil {
+			return nil, options.ctx.Err()
+		}
 
-		time.Sleep(time.Duration(math.Pow(2, float64(i))) * time.Duration(options.backoff) * time.Millisecond)
+		delay := time.Duration(math.Min(
+			math.Pow(2, float64(i))*float64(time.Duration(options.backoff)*time.Millisecond),
+			float64(math.MaxInt64),
+		))
+		if app.maxBackoff > 0 && delay > app.maxBackoff {
+			delay = app.maxBackoff
+		}
+		if options.jitter && delay > 0 {
+			delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+		}
+		select {
+		case <-options.ctx.Done():
+			return nil, options.ctx.Err()
+		case <-time.After(delay):
+		}
 	}
 
-	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
+		if retryable && attempts > 1 {
+			return nil, fmt.Errorf("all %d retries exhausted: %w", attempts, err)
+		}
 		return nil, err
 	}
+	if options.response != nil {
+		*options.response = resp
+	}
 
 	statusCode := resp.StatusCode
-	if statusCode != 200 {
-		return nil, app.handleError(statusCode, respBody, action)
+	if statusCode >= 200 && statusCode < 300 && !retryable {
+		return respBody, nil
 	}
 
+	if statusCode < 200 || statusCode >= 300 {
+		err = app.handleError(statusCode, respBody, action)
+	} else {
+		// A custom retry policy rejected a successful response on the last attempt.
+		err = &FirecrawlError{
+			StatusCode: statusCode,
+			Action:     action,
+			Message:    fmt.Sprintf("failed to %s: the retry policy rejected the response with status code %d", action, statusCode),
+		}
+	}
+	var firecrawlErr *FirecrawlError
+	if app.debugRequestBodies && errors.As(err, &firecrawlErr) {
+		firecrawlErr.RequestBody = body
+	}
+	if retryable && attempts > 1 {
+		return nil, fmt.Errorf("all %d retries exhausted: %w", attempts, err)
+	}
+	return nil, err
+}
+
+// defaultRetryPolicy retries requests that received a 502 Bad Gateway response.
+//
+// Parameters:
+//   - resp: The HTTP response, or nil if the request failed.
+//   - err: The error returned by the HTTP client, if any.
+//
+// Returns:
+//   - bool: True if the request should be retried.
+func defaultRetryPolicy(resp *http.Response, err error) bool {
+	return err == nil && resp.StatusCode == 502
+}
+
+// statusCheckOptions returns the request options used for crawl status checks.
+//
+// Returns:
+//   - []requestOption: The retry, backoff and jitter options for status checks.
+func (app *FirecrawlApp) statusCheckOptions() []requestOption {
+	retries := app.statusCheckRetries
+	if retries <= 0 {
+		retries = 3
+	}
+	backoff := app.statusCheckBackoff
+	if backoff <= 0 {
+		backoff = 500
+	}
+	return []requestOption{withRetries(retries), withBackoff(backoff), withJitter(app.statusCheckJitter)}
+}
+
+// unmarshalResponse decodes a response body into v. If the app was created with WithUseNumber,
+// numbers decoded into untyped values (any, map[string]any) are kept as json.Number.
+//
+// Parameters:
+//   - body: The response body to decode.
+//   - v: The value to decode into.
+//
+// Returns:
+//   - error: An error if the body cannot be decoded.
+func (app *FirecrawlApp) unmarshalResponse(body []byte, v any) error {
+	if !app.useNumber {
+		return json.Unmarshal(body, v)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+
+	// Documents are decoded by their own UnmarshalJSON, which does not see the decoder settings,
+	// so the values returned by their scripts are decoded again.
+	var docs []*FirecrawlDocument
+	switch v := v.(type) {
+	case *ScrapeResponse:
+		docs = []*FirecrawlDocument{v.Data}
+	case *CrawlStatusResponse:
+		docs = v.Data
+	case *BatchScrapeStatusResponse:
+		docs = v.Data
+	}
+	for _, doc := range docs {
+		if doc == nil || doc.Actions == nil {
+			continue
+		}
+		for i := range doc.Actions.JavascriptReturns {
+			ret := &doc.Actions.JavascriptReturns[i]
+			if len(ret.raw) == 0 {
+				continue
+			}
+			decoder := json.NewDecoder(bytes.NewReader(ret.raw))
+			decoder.UseNumber()
+			if err := decoder.Decode(&ret.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readResponseBody reads a response body, enforcing the app's maximum response size if one is set.
+//
+// Parameters:
+//   - body: The response body to read.
+//
+// Returns:
+//   - []byte: The response body.
+//   - error: An error if reading fails or the body exceeds the maximum response size.
+func (app *FirecrawlApp) readResponseBody(body io.Reader) ([]byte, error) {
+	if app.maxResponseSize <= 0 {
+		return io.ReadAll(body)
+	}
+
+	respBody, err := io.ReadAll(io.LimitReader(body, app.maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(respBody)) > app.maxResponseSize {
+		return nil, fmt.Errorf("response body exceeds maximum size of %d bytes", app.maxResponseSize)
+	}
 	return respBody, nil
 }
 
-// monitorJobStatus monitors the status of a crawl job using the Firecrawl API.
+// gzipBytes compresses data with gzip.
 //
 // Parameters:
-//   - ID: The ID of the crawl job to monitor.
+//   - data: The data to compress.
+//
+// Returns:
+//   - []byte: The compressed data.
+//   - error: An error if compression fails.
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// defaultMaxStatusPages is the default maximum number of pages fetched for a completed job's status.
+const defaultMaxStatusPages = 1000
+
+// defaultEmptyResultRetries is the default number of times a completed job without data is polled again.
+const defaultEmptyResultRetries = 3
+
+// monitorJobStatus monitors the status of a crawl or batch scrape job using the Firecrawl API.
+// If the context is done while the job is running, the last status received is returned along with the context's error.
+// Once the job is completed, the pages of its status are followed until there is no next page. Following
+// fails if a next page was already fetched or the job has more pages than allowed by WithMaxStatusPages.
+// If a page cannot be fetched, the documents received so far are returned along with a *StatusPageError.
+// A job reported as completed before its data is available is polled again, as set by WithEmptyResultRetries.
+// Only the "failed" and "cancelled" statuses end monitoring with an error; any other status, such as
+// "scraping" or one the API introduces later, is treated as in progress and received by onProgress,
+// unless WithRunningStatuses restricts the in-progress statuses. Monitoring stops after the time set
+// by WithMaxJobWait, as if the context was done.
+//
+// Parameters:
+//   - ctx: The context used to stop monitoring the job.
+//   - statusURL: The URL used to check the job status.
+//   - job: A string describing the kind of job being monitored (e.g., "crawl").
 //   - headers: The headers to be included in the request.
 //   - pollInterval: The interval (in seconds) at which to poll the job status.
+//   - onProgress: An optional callback invoked with every status received while polling (can be nil).
 //
 // Returns:
-//   - *CrawlStatusResponse: The crawl result if the job is completed.
-//   - error: An error if the crawl status check request fails.
-func (app *FirecrawlApp) monitorJobStatus(ID string, headers map[string]string, pollInterval int) (*CrawlStatusResponse, error) {
-	attempts := 3
+//   - *CrawlStatusResponse: The job result if the job is completed.
+//   - error: An error if the job status check request fails.
+func (app *FirecrawlApp) monitorJobStatus(ctx context.Context, statusURL string, job string, headers map[string]string, pollInterval int, onProgress func(*CrawlStatusResponse)) (*CrawlStatusResponse, error) {
+	if app.maxJobWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, app.maxJobWait)
+		defer cancel()
+	}
+	emptyAttempts := 0
+	emptyRetries := defaultEmptyResultRetries
+	if app.emptyResultRetries != nil {
+		emptyRetries = *app.emptyResultRetries
+	}
+	emptyDelay := time.Duration(max(pollInterval, 2)) * time.Second
+	if app.emptyResultDelay > 0 {
+		emptyDelay = app.emptyResultDelay
+	}
+	var lastStatus *CrawlStatusResponse
 
 	for {
 		resp, err := app.makeRequest(
 			http.MethodGet,
-			fmt.Sprintf("%s/v1/crawl/%s", app.APIURL, ID),
+			statusURL,
 			nil,
 			headers,
-			"check crawl status",
-			withRetries(3),
-			withBackoff(500),
+			fmt.Sprintf("check %s status", job),
+			append(app.statusCheckOptions(), withContext(ctx))...,
 		)
 		if err != nil {
+			if ctx.Err() != nil {
+				return lastStatus, ctx.Err()
+			}
 			return nil, err
 		}
 
 		var statusData CrawlStatusResponse
-		err = json.Unmarshal(resp, &statusData)
+		err = app.unmarshalResponse(resp, &statusData)
 		if err != nil {
 			return nil, err
 		}
+		lastStatus = &statusData
+		if onProgress != nil {
+			onProgress(&statusData)
+		}
 
 		status := statusData.Status
 		if status == "" {
@@ -707,45 +2006,118 @@ func (app *FirecrawlApp) monitorJobStatus(ID string, headers map[string]string,
 		}
 		if status == "completed" {
 			if statusData.Data != nil {
-				allData := statusData.Data
-				for statusData.Next != nil {
-					resp, err := app.makeRequest(
-						http.MethodGet,
-						*statusData.Next,
-						nil,
-						headers,
-						"fetch next page of crawl status",
-						withRetries(3),
-						withBackoff(500),
-					)
-					if err != nil {
-						return nil, err
-					}
-
-					err = json.Unmarshal(resp, &statusData)
-					if err != nil {
-						return nil, err
-					}
-
-					if statusData.Data != nil {
-						allData = append(allData, statusData.Data...)
-					}
-				}
-				statusData.Data = allData
-				return &statusData, nil
+				return app.followStatusPages(ctx, statusURL, &statusData, job, headers)
 			} else {
-				attempts++
-				if attempts > 3 {
-					return nil, fmt.Errorf("crawl job completed but no data was returned")
+				emptyAttempts++
+				if emptyAttempts > emptyRetries {
+					return nil, fmt.Errorf("%s job completed but no data was returned", job)
+				}
+				select {
+				case <-ctx.Done():
+					return lastStatus, ctx.Err()
+				case <-time.After(emptyDelay):
 				}
 			}
-		} else if status == "active" || status == "paused" || status == "pending" || status == "queued" || status == "waiting" || status == "scraping" {
-			pollInterval = max(pollInterval, 2)
-			time.Sleep(time.Duration(pollInterval) * time.Second)
+		} else if status == "failed" || status == "cancelled" {
+			return nil, fmt.Errorf("%s job failed or was stopped. Status: %s", job, status)
+		} else if app.runningStatuses != nil && !app.runningStatuses[status] {
+			return lastStatus, fmt.Errorf("%s job has an unexpected status: %s", job, status)
 		} else {
-			return nil, fmt.Errorf("crawl job failed or was stopped. Status: %s", status)
+			// Any other status, including ones added to the API later, means the job is still running.
+			pollInterval = max(pollInterval, 2)
+			select {
+			case <-ctx.Done():
+				return lastStatus, ctx.Err()
+			case <-time.After(time.Duration(pollInterval) * time.Second):
+			}
+		}
+	}
+}
+
+// ResumeStatusPages fetches the remaining pages of a completed crawl or batch scrape status that
+// was returned together with a *StatusPageError, appending their documents to the ones already
+// received. A batch scrape status can be resumed by converting it with (*CrawlStatusResponse)(status).
+//
+// Parameters:
+//   - ctx: The context used to stop fetching pages.
+//   - status: The partial status, whose Next is the first page still to fetch.
+//
+// Returns:
+//   - *CrawlStatusResponse: The status with the documents of all pages.
+//   - error: A *StatusPageError, along with the documents received so far, if a page fails again.
+func (app *FirecrawlApp) ResumeStatusPages(ctx context.Context, status *CrawlStatusResponse) (*CrawlStatusResponse, error) {
+	if status == nil {
+		return nil, fmt.Errorf("cannot resume a nil status")
+	}
+	if status.Next == nil {
+		return status, nil
+	}
+	return app.followStatusPages(ctx, "", status, "job", app.prepareHeaders(nil))
+}
+
+// followStatusPages follows the next pages of a completed job's status and collects their documents.
+// Every page is fetched with the status check retry policy. If a page still fails or cannot be
+// decoded, the documents received so far are returned with Next set to the failed page, along with
+// a *StatusPageError, so the download can be resumed with ResumeStatusPages.
+//
+// Parameters:
+//   - ctx: The context used to stop fetching pages.
+//   - statusURL: The URL of the first page, or an empty string if unknown.
+//   - statusData: The first page of the status.
+//   - job: A string describing the kind of job (e.g., "crawl").
+//   - headers: The headers to be included in the requests.
+//
+// Returns:
+//   - *CrawlStatusResponse: The status with the documents of all pages.
+//   - error: An error if a page fails or the pages never end.
+func (app *FirecrawlApp) followStatusPages(ctx context.Context, statusURL string, statusData *CrawlStatusResponse, job string, headers map[string]string) (*CrawlStatusResponse, error) {
+	allData := statusData.Data
+	maxPages := app.maxStatusPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxStatusPages
+	}
+	seen := map[string]bool{statusURL: true}
+	for pages := 1; statusData.Next != nil; pages++ {
+		next := *statusData.Next
+		if seen[next] {
+			return nil, fmt.Errorf("%s status returned a next page that was already fetched: %s", job, next)
+		}
+		if pages >= maxPages {
+			return nil, fmt.Errorf("%s status has more than %d pages", job, maxPages)
 		}
+		seen[next] = true
+
+		resp, err := app.makeRequest(
+			http.MethodGet,
+			next,
+			nil,
+			headers,
+			fmt.Sprintf("fetch next page of %s status", job),
+			append(app.statusCheckOptions(), withContext(ctx))...,
+		)
+		if err != nil {
+			partial := *statusData
+			partial.Data = allData
+			if ctx.Err() != nil {
+				return &partial, ctx.Err()
+			}
+			return &partial, &StatusPageError{URL: next, Err: err}
+		}
+
+		var page CrawlStatusResponse
+		err = app.unmarshalResponse(resp, &page)
+		if err != nil {
+			partial := *statusData
+			partial.Data = allData
+			return &partial, &StatusPageError{URL: next, Err: err}
+		}
+
+		allData = append(allData, page.Data...)
+		statusData = &page
 	}
+	result := *statusData
+	result.Data = allData
+	return &result, nil
 }
 
 // handleError handles errors returned by the Firecrawl API.
@@ -756,7 +2128,7 @@ func (app *FirecrawlApp) monitorJobStatus(ID string, headers map[string]string,
 //   - action: A string describing the action being performed.
 //
 // Returns:
-//   - error: An error describing the failure reason.
+//   - error: A *FirecrawlError describing the failure reason.
 func (app *FirecrawlApp) handleError(statusCode int, body []byte, action string) error {
 	var errorData map[string]any
 	err := json.Unmarshal(body, &errorData)
@@ -769,8 +2141,19 @@ func (app *FirecrawlApp) handleError(statusCode int, body []byte, action string)
 		errorMessage = "No additional error details provided."
 	}
 
+	details := parseErrorDetails(body)
+
 	var message string
 	switch statusCode {
+	case 400:
+		message = fmt.Sprintf("Bad Request: Failed to %s. %s", action, errorMessage)
+		if len(details) > 0 {
+			detailMessages := make([]string, len(details))
+			for i, detail := range details {
+				detailMessages[i] = detail.String()
+			}
+			message = fmt.Sprintf("%s (%s)", message, strings.Join(detailMessages, "; "))
+		}
 	case 402:
 		message = fmt.Sprintf("Payment Required: Failed to %s. %s", action, errorMessage)
 	case 408:
@@ -783,5 +2166,10 @@ func (app *FirecrawlApp) handleError(statusCode int, body []byte, action string)
 		message = fmt.Sprintf("Unexpected error during %s: Status code %d. %s", action, statusCode, errorMessage)
 	}
 
-	return fmt.Errorf(message)
+	return &FirecrawlError{
+		StatusCode: statusCode,
+		Action:     action,
+		Message:    message,
+		Details:    details,
+	}
 }
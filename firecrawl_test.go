@@ -1,8 +1,18 @@
 package firecrawl
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -107,6 +117,41 @@ func TestSuccessfulResponseWithValidAPIKeyAndIncludeHTML(t *testing.T) {
 	assert.NotNil(t, response.Metadata)
 }
 
+func TestScrapeURLWithWaitForSelectorActionE2E(t *testing.T) {
+	app, err := NewFirecrawlApp(TEST_API_KEY, API_URL)
+	require.NoError(t, err)
+
+	params := ScrapeParams{
+		Actions: []Action{
+			{Type: ActionTypeWait, Selector: ptr("h1")},
+		},
+	}
+
+	response, err := app.ScrapeURL("https://roastmywebsite.ai", &params)
+	require.NoError(t, err)
+	assert.NotNil(t, response)
+
+	assert.Contains(t, response.Markdown, "_Roast_")
+}
+
+func TestScrapeURLWithLocationE2E(t *testing.T) {
+	app, err := NewFirecrawlApp(TEST_API_KEY, API_URL)
+	require.NoError(t, err)
+
+	params := ScrapeParams{
+		Location: &LocationParams{
+			Country:   ptr("DE"),
+			Languages: []string{"de"},
+		},
+	}
+
+	response, err := app.ScrapeURL("https://roastmywebsite.ai", &params)
+	require.NoError(t, err)
+	assert.NotNil(t, response)
+
+	assert.Contains(t, response.Markdown, "_Roast_")
+}
+
 func TestSuccessfulResponseForValidScrapeWithPDFFile(t *testing.T) {
 	app, err := NewFirecrawlApp(TEST_API_KEY, API_URL)
 	require.NoError(t, err)
@@ -424,3 +469,960 @@ func TestMapURLWithSearchParameter(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Search is not implemented in API version 1.0.0")
 }
+
+func TestCheckCrawlStatusWithActionScreenshots(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"completed","total":1,"completed":1,"data":[{"markdown":"# Page","actions":{"screenshots":["https://example.com/1.png","https://example.com/2.png"]}}]}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	response, err := app.CheckCrawlStatus("job-1")
+	require.NoError(t, err)
+	require.Len(t, response.Data, 1)
+	require.NotNil(t, response.Data[0].Actions)
+	assert.Equal(t, []string{"https://example.com/1.png", "https://example.com/2.png"}, response.Data[0].Actions.Screenshots)
+}
+
+func TestCheckCrawlStatusRaw(t *testing.T) {
+	body := `{"status":"scraping","total":3,"completed":1,"newServerField":{"nested":true}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/crawl/job-1", r.URL.Path)
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	raw, err := app.CheckCrawlStatusRaw("job-1")
+	require.NoError(t, err)
+	assert.JSONEq(t, body, string(raw))
+}
+
+func TestCheckCrawlStatusWithParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/crawl/job-1", r.URL.Path)
+		assert.Equal(t, "10", r.URL.Query().Get("skip"))
+		assert.Equal(t, "5", r.URL.Query().Get("limit"))
+		fmt.Fprint(w, `{"status":"completed","total":20,"completed":20,"data":[{"markdown":"# Page"}]}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	status, err := app.CheckCrawlStatusWithParams("job-1", &StatusParams{Skip: ptr(10), Limit: ptr(5)})
+	require.NoError(t, err)
+	assert.Equal(t, "completed", status.Status)
+	require.Len(t, status.Data, 1)
+}
+
+func TestCloneWithAPIKey(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		assert.Equal(t, "tenants", r.Header.Get("X-Origin"))
+		fmt.Fprint(w, `{"success":true,"data":{"markdown":"# Example"}}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-default", server.URL,
+		WithOrigin("tenants"),
+		WithRateLimit(100, 10),
+		WithRetryPolicy(func(resp *http.Response, err error) bool { return false }),
+		WithRunningStatuses("scraping"),
+	)
+	require.NoError(t, err)
+	tenant := app.CloneWithAPIKey("fc-tenant")
+
+	_, err = tenant.ScrapeURL("https://example.com", nil)
+	require.NoError(t, err)
+	_, err = app.ScrapeURL("https://example.com", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bearer fc-tenant", "Bearer fc-default"}, authorizations)
+
+	// Every setting except the API key is carried over to the copy.
+	original, clone := reflect.ValueOf(app).Elem(), reflect.ValueOf(tenant).Elem()
+	for i := 0; i < original.NumField(); i++ {
+		name := original.Type().Field(i).Name
+		if name == "APIKey" || name == "clientMu" {
+			continue
+		}
+		if kind := original.Field(i).Kind(); kind == reflect.Func || kind == reflect.Map {
+			assert.Equal(t, original.Field(i).Pointer(), clone.Field(i).Pointer(), name)
+			continue
+		}
+		assert.True(t, original.Field(i).Equal(clone.Field(i)), name)
+	}
+}
+
+func TestSetClient(t *testing.T) {
+	replay := func(markdown string) *http.Client {
+		return &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"success":true,"data":{"markdown":"` + markdown + `"}}`)),
+				Header:     make(http.Header),
+				Request:    req,
+			}, nil
+		})}
+	}
+
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithHTTPClient(replay("old")))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := app.ScrapeURL("https://example.com", nil)
+			assert.NoError(t, err)
+		}()
+	}
+	app.SetClient(replay("new"))
+	wg.Wait()
+
+	response, err := app.ScrapeURL("https://example.com", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "new", response.Markdown)
+}
+
+func TestCheckCrawlErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v1/crawl/job-1/errors", r.URL.Path)
+		fmt.Fprint(w, `{"errors":[{"id":"err-1","timestamp":"2024-10-01T12:00:00Z","url":"https://example.com/broken","error":"Request timed out"}],"robotsBlocked":["https://example.com/private"]}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	response, err := app.CheckCrawlErrors("job-1")
+	require.NoError(t, err)
+	require.Len(t, response.Errors, 1)
+	assert.Equal(t, "https://example.com/broken", response.Errors[0].URL)
+	assert.Equal(t, "Request timed out", response.Errors[0].Error)
+	assert.Equal(t, []string{"https://example.com/private"}, response.RobotsBlocked)
+}
+
+func TestMakeRequestRetrySendsBody(t *testing.T) {
+	var bodies []string
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+
+		statusCode, responseBody := http.StatusOK, `{"success":true,"id":"job-1"}`
+		if len(bodies) == 1 {
+			statusCode, responseBody = http.StatusBadGateway, `{"error":"Bad Gateway"}`
+		}
+		return &http.Response{
+			StatusCode: statusCode,
+			Body:       io.NopCloser(strings.NewReader(responseBody)),
+			Header:     make(http.Header),
+			Request:    req,
+		}, nil
+	})
+
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithTransport(transport), WithMaxBackoff(time.Millisecond))
+	require.NoError(t, err)
+
+	response, err := app.AsyncCrawlURL("https://example.com", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", response.ID)
+
+	require.Len(t, bodies, 2)
+	assert.Contains(t, bodies[0], `"url":"https://example.com"`)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestCancelCrawlJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/v1/crawl/job-1", r.URL.Path)
+		fmt.Fprint(w, `{"success":true,"status":"cancelled"}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	response, err := app.CancelCrawlJob("job-1")
+	require.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "cancelled", response.Status)
+
+	status, err := app.CancelCrawlJobStatus("job-1")
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", status)
+}
+
+func TestBatchScrapeURLsE2E(t *testing.T) {
+	app, err := NewFirecrawlApp(TEST_API_KEY, API_URL)
+	require.NoError(t, err)
+
+	response, err := app.BatchScrapeURLs([]string{"https://roastmywebsite.ai", "https://firecrawl.dev"}, &ScrapeParams{
+		Formats: []string{"markdown"},
+	}, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, response)
+
+	assert.Equal(t, "completed", response.Status)
+	assert.Len(t, response.Data, 2)
+	assert.Greater(t, response.CreditsUsed, 0)
+}
+
+func TestBatchScrapeURLsWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/v1/batch/scrape", r.URL.Path)
+			fmt.Fprint(w, `{"success":true,"id":"batch-1","url":"https://api.firecrawl.dev/v1/batch/scrape/batch-1"}`)
+		default:
+			assert.Equal(t, "/v1/batch/scrape/batch-1", r.URL.Path)
+			fmt.Fprint(w, `{"status":"completed","total":2,"completed":2,"data":[{"markdown":"one"},{"markdown":"two"}]}`)
+		}
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	response, err := app.BatchScrapeURLsWithContext(context.Background(), []string{"https://example.com/1", "https://example.com/2"}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", response.Status)
+	assert.Len(t, response.Data, 2)
+}
+
+func TestBatchScrapeURLsRepeatedNextPage(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			fmt.Fprint(w, `{"success":true,"id":"batch-1"}`)
+		default:
+			fmt.Fprintf(w, `{"status":"completed","total":2,"completed":2,"next":"%s/v1/batch/scrape/batch-1?skip=1","data":[{"markdown":"one"}]}`, server.URL)
+		}
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	_, err = app.BatchScrapeURLs([]string{"https://example.com/1", "https://example.com/2"}, nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already fetched")
+}
+
+func TestBatchScrapeURLsMaxStatusPages(t *testing.T) {
+	var pages int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			fmt.Fprint(w, `{"success":true,"id":"batch-1"}`)
+		default:
+			page := atomic.AddInt32(&pages, 1)
+			fmt.Fprintf(w, `{"status":"completed","total":100,"completed":100,"next":"%s/v1/batch/scrape/batch-1?skip=%d","data":[{"markdown":"page"}]}`, server.URL, page)
+		}
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithMaxStatusPages(3))
+	require.NoError(t, err)
+
+	_, err = app.BatchScrapeURLs([]string{"https://example.com/1"}, nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "more than 3 pages")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&pages))
+}
+
+func TestBatchScrapeURLsFailedStatusPage(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			fmt.Fprint(w, `{"success":true,"id":"batch-1"}`)
+		case r.URL.Query().Get("skip") == "":
+			fmt.Fprintf(w, `{"status":"completed","total":3,"completed":3,"next":"%s/v1/batch/scrape/batch-1?skip=1","data":[{"markdown":"one"}]}`, server.URL)
+		case r.URL.Query().Get("skip") == "1" && failing.Load():
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error":"Internal Server Error"}`)
+		case r.URL.Query().Get("skip") == "1":
+			fmt.Fprintf(w, `{"status":"completed","total":3,"completed":3,"next":"%s/v1/batch/scrape/batch-1?skip=2","data":[{"markdown":"two"}]}`, server.URL)
+		default:
+			fmt.Fprint(w, `{"status":"completed","total":3,"completed":3,"data":[{"markdown":"three"}]}`)
+		}
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	response, err := app.BatchScrapeURLs([]string{"https://example.com/1"}, nil, nil)
+	var pageErr *StatusPageError
+	require.ErrorAs(t, err, &pageErr)
+	assert.Equal(t, server.URL+"/v1/batch/scrape/batch-1?skip=1", pageErr.URL)
+	require.NotNil(t, response)
+	require.Len(t, response.Data, 1)
+	require.NotNil(t, response.Next)
+	assert.Equal(t, pageErr.URL, *response.Next)
+
+	failing.Store(false)
+	resumed, err := app.ResumeStatusPages(context.Background(), (*CrawlStatusResponse)(response))
+	require.NoError(t, err)
+	assert.Nil(t, resumed.Next)
+	require.Len(t, resumed.Data, 3)
+	assert.Equal(t, "three", resumed.Data[2].Markdown)
+}
+
+func TestResumeStatusPagesUndecodablePage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("skip") == "1" {
+			fmt.Fprint(w, `{"status":"completed","data":[{"markdown":"two"}]`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"completed","next":"http://%s/v1/crawl/job-1?skip=1","data":[{"markdown":"one"}]}`, r.Host)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	next := server.URL + "/v1/crawl/job-1?skip=0"
+	response, err := app.ResumeStatusPages(context.Background(), &CrawlStatusResponse{Status: "completed", Next: &next})
+	var pageErr *StatusPageError
+	require.ErrorAs(t, err, &pageErr)
+	assert.Equal(t, server.URL+"/v1/crawl/job-1?skip=1", pageErr.URL)
+	require.NotNil(t, response)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "one", response.Data[0].Markdown)
+	require.NotNil(t, response.Next)
+	assert.Equal(t, pageErr.URL, *response.Next)
+}
+
+func TestBatchScrapeURLsEmptyCompletedResult(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			fmt.Fprint(w, `{"success":true,"id":"batch-1"}`)
+			return
+		}
+		if atomic.AddInt32(&polls, 1) <= 2 {
+			fmt.Fprint(w, `{"status":"completed","total":1,"completed":1}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"completed","total":1,"completed":1,"data":[{"markdown":"one"}]}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithEmptyResultRetries(3, time.Millisecond))
+	require.NoError(t, err)
+
+	response, err := app.BatchScrapeURLs([]string{"https://example.com/1"}, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&polls))
+
+	atomic.StoreInt32(&polls, 0)
+	app, err = NewFirecrawlApp("fc-test", server.URL, WithEmptyResultRetries(1, time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = app.BatchScrapeURLs([]string{"https://example.com/1"}, nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "completed but no data was returned")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&polls))
+}
+
+func TestBatchScrapeURLsWithContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			fmt.Fprint(w, `{"success":true,"id":"batch-1"}`)
+		default:
+			fmt.Fprint(w, `{"status":"scraping","total":2,"completed":1,"data":[{"markdown":"one"}]}`)
+		}
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	response, err := app.BatchScrapeURLsWithContext(ctx, []string{"https://example.com/1", "https://example.com/2"}, nil, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotNil(t, response)
+	assert.Equal(t, "scraping", response.Status)
+	assert.Len(t, response.Data, 1)
+}
+
+func TestScrapeURLs(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body["url"] == "https://example.com/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error":"boom"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"success":true,"data":{"markdown":%q}}`, body["url"])
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	urls := []string{
+		"https://example.com/1",
+		"https://example.com/broken",
+		"https://example.com/3",
+		"https://example.com/4",
+		"https://example.com/5",
+	}
+	documents, errs := app.ScrapeURLs(context.Background(), urls, nil, 2)
+	require.Len(t, documents, len(urls))
+	require.Len(t, errs, len(urls))
+
+	for i, url := range urls {
+		if url == "https://example.com/broken" {
+			assert.Nil(t, documents[i])
+			assert.Error(t, errs[i])
+			continue
+		}
+		require.NoError(t, errs[i])
+		assert.Equal(t, url, documents[i].Markdown)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+func TestMakeRequestAcceptsAny2xxStatus(t *testing.T) {
+	for _, statusCode := range []int{http.StatusOK, http.StatusCreated, http.StatusAccepted} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(statusCode)
+			fmt.Fprint(w, `{"success":true,"id":"job-1","url":"https://api.firecrawl.dev/v1/crawl/job-1"}`)
+		}))
+
+		app, err := NewFirecrawlApp("fc-test", server.URL)
+		require.NoError(t, err)
+
+		response, err := app.AsyncCrawlURL("https://example.com", nil, nil)
+		require.NoError(t, err, "status code %d", statusCode)
+		assert.Equal(t, "job-1", response.ID)
+
+		server.Close()
+	}
+}
+
+func TestScrapeURLWithScrapeActions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []any{
+			map[string]any{"type": "scrape"},
+			map[string]any{"type": "wait", "milliseconds": float64(500)},
+			map[string]any{"type": "scrape"},
+		}, body["actions"])
+
+		fmt.Fprint(w, `{"success":true,"data":{"markdown":"final","actions":{"scrapes":[{"url":"https://example.com","html":"<p>step 1</p>"},{"url":"https://example.com","html":"<p>step 2</p>"}]}}}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	response, err := app.ScrapeURL("https://example.com", &ScrapeParams{
+		Actions: []Action{
+			{Type: ActionTypeScrape},
+			{Type: ActionTypeWait, Milliseconds: ptr(500)},
+			{Type: ActionTypeScrape},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, response.Actions)
+	require.Len(t, response.Actions.Scrapes, 2)
+	assert.Equal(t, "<p>step 1</p>", response.Actions.Scrapes[0].HTML)
+	assert.Equal(t, "<p>step 2</p>", response.Actions.Scrapes[1].HTML)
+}
+
+func TestScrapeURLWithExecuteJavascriptAction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []any{
+			map[string]any{"type": "executeJavascript", "script": "return window.__PRICE__"},
+		}, body["actions"])
+
+		fmt.Fprint(w, `{"success":true,"data":{"markdown":"# Shoe","actions":{"javascriptReturns":[{"type":"object","value":{"amount":49.99,"currency":"EUR"}}]}}}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	response, err := app.ScrapeURL("https://example.com", &ScrapeParams{
+		Actions: []Action{{Type: ActionTypeExecuteJavascript, Script: ptr("return window.__PRICE__")}},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, response.Actions)
+	require.Len(t, response.Actions.JavascriptReturns, 1)
+	assert.Equal(t, "object", response.Actions.JavascriptReturns[0].Type)
+	assert.Equal(t, map[string]any{"amount": 49.99, "currency": "EUR"}, response.Actions.JavascriptReturns[0].Value)
+}
+
+func TestScrapeURLRaw(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		if strings.Contains(r.URL.Path, "scrape") && r.Header.Get("Authorization") == "Bearer fc-broke" {
+			w.WriteHeader(http.StatusPaymentRequired)
+			fmt.Fprint(w, `{"error":"Insufficient credits"}`)
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"data":{"markdown":"# Raw"}}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	doc, resp, err := app.ScrapeURLRaw("https://example.com", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "# Raw", doc.Markdown)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "req-1", resp.Header.Get("X-Request-Id"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"success":true,"data":{"markdown":"# Raw"}}`, string(body))
+
+	app, err = NewFirecrawlApp("fc-broke", server.URL)
+	require.NoError(t, err)
+
+	doc, resp, err = app.ScrapeURLRaw("https://example.com", nil)
+	assert.Error(t, err)
+	assert.Nil(t, doc)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusPaymentRequired, resp.StatusCode)
+}
+
+func TestFetchOGImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/og.png":
+			w.Header().Set("Content-Type", "image/png")
+			fmt.Fprint(w, "png-bytes")
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, "<html></html>")
+		}
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev")
+	require.NoError(t, err)
+
+	doc := &FirecrawlDocument{Metadata: &FirecrawlDocumentMetadata{
+		ResolvedURL: ptr(server.URL + "/blog/post"),
+		OGImage:     ptr("/og.png"),
+	}}
+	data, contentType, err := app.FetchOGImage(context.Background(), doc, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "png-bytes", string(data))
+	assert.Equal(t, "image/png", contentType)
+
+	_, _, err = app.FetchOGImage(context.Background(), doc, 4)
+	assert.ErrorContains(t, err, "exceeds maximum size")
+
+	doc.Metadata.OGImage = ptr(server.URL + "/page")
+	_, _, err = app.FetchOGImage(context.Background(), doc, 0)
+	assert.ErrorContains(t, err, "is not an image")
+
+	doc = &FirecrawlDocument{Metadata: &FirecrawlDocumentMetadata{
+		SourceURL: ptr(server.URL + "/blog/post"),
+		OGImage:   ptr("/og.png"),
+	}}
+	data, _, err = app.FetchOGImage(context.Background(), doc, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "png-bytes", string(data))
+
+	_, _, err = app.FetchOGImage(context.Background(), &FirecrawlDocument{}, 0)
+	assert.ErrorContains(t, err, "no OG image")
+}
+
+func TestFetchSourceFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		if r.URL.Path != "/report.pdf" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		fmt.Fprint(w, "%PDF-1.7")
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev")
+	require.NoError(t, err)
+
+	doc := &FirecrawlDocument{Metadata: &FirecrawlDocumentMetadata{
+		SourceURL:   ptr(server.URL + "/latest-report"),
+		ResolvedURL: ptr(server.URL + "/report.pdf"),
+	}}
+	data, contentType, err := app.FetchSourceFile(context.Background(), doc, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.7", string(data))
+	assert.Equal(t, "application/pdf", contentType)
+
+	doc.Metadata.ResolvedURL = nil
+	_, _, err = app.FetchSourceFile(context.Background(), doc, 0)
+	assert.ErrorContains(t, err, "status code 404")
+
+	_, _, err = app.FetchSourceFile(context.Background(), &FirecrawlDocument{}, 0)
+	assert.ErrorContains(t, err, "no URL")
+}
+
+func TestScrapeURLWithFastMode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, true, body["fastMode"])
+
+		fmt.Fprint(w, `{"success":true,"data":{"markdown":"# Fast"}}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	response, err := app.ScrapeURL("https://example.com", &ScrapeParams{FastMode: ptr(true)})
+	require.NoError(t, err)
+	assert.Equal(t, "# Fast", response.Markdown)
+}
+
+func TestScrapeParamsWaitStrategy(t *testing.T) {
+	body := map[string]any{}
+	addScrapeParams(body, &ScrapeParams{WaitFor: ptr(5000), Wait: WaitForDuration(1000)})
+	assert.Equal(t, map[string]any{"waitFor": ptr(1000)}, body)
+
+	body = map[string]any{}
+	addScrapeParams(body, &ScrapeParams{
+		WaitFor: ptr(5000),
+		Wait:    WaitForSelector("#prices"),
+		Actions: []Action{{Type: ActionTypeScreenshot}},
+	})
+	assert.Equal(t, map[string]any{
+		"actions": []Action{{Type: ActionTypeWait, Selector: ptr("#prices")}, {Type: ActionTypeScreenshot}},
+	}, body)
+
+	var crawlBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&crawlBody))
+		fmt.Fprint(w, `{"success":true,"id":"job-1"}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	_, err = app.AsyncCrawlURL("https://example.com", &CrawlParams{
+		ScrapeOptions: ScrapeParams{Formats: []string{"markdown"}, Wait: WaitForSelector("main")},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"formats": []any{"markdown"},
+		"actions": []any{map[string]any{"type": "wait", "selector": "main"}},
+	}, crawlBody["scrapeOptions"])
+}
+
+func TestScrapeParamsSlowFormatTimeout(t *testing.T) {
+	body := map[string]any{}
+	addScrapeParams(body, &ScrapeParams{Formats: []string{"markdown"}})
+	assert.NotContains(t, body, "timeout")
+
+	body = map[string]any{}
+	addScrapeParams(body, &ScrapeParams{Formats: []string{"markdown", "screenshot@fullPage"}})
+	assert.Equal(t, slowFormatTimeout, body["timeout"])
+
+	body = map[string]any{}
+	addScrapeParams(body, &ScrapeParams{Formats: []string{"json"}, Timeout: ptr(20000)})
+	assert.Equal(t, ptr(20000), body["timeout"])
+}
+
+func TestScrapeParamsJSONRoundTrip(t *testing.T) {
+	params := ScrapeParams{Formats: []string{"json"}, WaitFor: ptr(5000)}
+
+	data, err := json.Marshal(params)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"formats":["json"],"waitFor":5000}`, string(data))
+
+	var decoded ScrapeParams
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, params, decoded)
+}
+
+func TestMakeRequestRetriesExhausted(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{"error":"Bad Gateway"}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	_, err = app.makeRequest(http.MethodGet, server.URL, nil, nil, "check crawl status", withRetries(3), withBackoff(1))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "all 3 retries exhausted")
+	assert.Contains(t, err.Error(), "Status code 502")
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	_, err = app.makeRequest(http.MethodGet, server.URL, nil, nil, "check crawl status", withRetries(0))
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestMakeRequestBackoffCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		cancel()
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{"error":"Bad Gateway"}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = app.makeRequest(http.MethodGet, server.URL, nil, nil, "check crawl status", withContext(ctx), withRetries(3), withBackoff(60000))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotContains(t, err.Error(), "retries exhausted")
+	assert.Equal(t, 1, attempts)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
+
+type trackedBody struct {
+	io.Reader
+	closed *bool
+}
+
+func (b trackedBody) Close() error {
+	*b.closed = true
+	return nil
+}
+
+type closeTrackingTransport struct {
+	t      *testing.T
+	closed []*bool
+}
+
+func (rt *closeTrackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for i, closed := range rt.closed {
+		assert.True(rt.t, *closed, "body of attempt %d was not closed before the next attempt", i+1)
+	}
+
+	statusCode, body := http.StatusBadGateway, `{"error":"Bad Gateway"}`
+	if len(rt.closed) == 2 {
+		statusCode, body = http.StatusOK, `{"status":"scraping"}`
+	}
+	closed := false
+	rt.closed = append(rt.closed, &closed)
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       trackedBody{Reader: strings.NewReader(body), closed: &closed},
+		Header:     make(http.Header),
+		Request:    req,
+	}, nil
+}
+
+func TestMakeRequestClosesBodiesBetweenRetries(t *testing.T) {
+	transport := &closeTrackingTransport{t: t}
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev")
+	require.NoError(t, err)
+	app.Client = &http.Client{Transport: transport}
+
+	_, err = app.makeRequest(http.MethodGet, "https://api.firecrawl.dev/v1/crawl/job-1", nil, nil, "check crawl status", withRetries(3), withBackoff(1))
+	require.NoError(t, err)
+
+	require.Len(t, transport.closed, 3)
+	for _, closed := range transport.closed {
+		assert.True(t, *closed)
+	}
+}
+
+func TestGetUsageHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/team/credit-usage/historical", r.URL.Path)
+		fmt.Fprint(w, `{"success":true,"periods":[
+			{"startDate":"2026-07-01T00:00:00Z","endDate":"2026-07-31T23:59:59Z","creditsUsed":100},
+			{"startDate":"2026-08-01T00:00:00Z","endDate":"2026-08-31T23:59:59Z","creditsUsed":200},
+			{"startDate":"2026-09-01T00:00:00Z","endDate":"2026-09-30T23:59:59Z","creditsUsed":300}
+		]}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	response, err := app.GetUsageHistory(
+		time.Date(2026, 8, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC),
+	)
+	require.NoError(t, err)
+	require.Len(t, response.Periods, 2)
+	assert.Equal(t, 200, response.Periods[0].CreditsUsed)
+	assert.Equal(t, 300, response.Periods[1].CreditsUsed)
+
+	_, err = app.GetUsageHistory(time.Now(), time.Now().Add(-time.Hour))
+	assert.Error(t, err)
+}
+
+func TestAsyncCrawlURLSendsCrawlParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []any{`.*\?page=\d+$`}, body["excludePaths"])
+		assert.Equal(t, true, body["regexOnFullURL"])
+		assert.Equal(t, true, body["ignoreQueryParameters"])
+		assert.Equal(t, true, body["allowSubdomains"])
+		assert.Equal(t, false, body["deduplicateSimilarURLs"])
+
+		fmt.Fprint(w, `{"success":true,"id":"job-1"}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	_, err = app.AsyncCrawlURL("https://example.com", &CrawlParams{
+		ExcludePaths:           []string{`.*\?page=\d+$`},
+		RegexOnFullURL:         ptr(true),
+		IgnoreQueryParameters:  ptr(true),
+		IncludeSubdomains:      ptr(true),
+		DeduplicateSimilarURLs: ptr(false),
+	}, nil)
+	require.NoError(t, err)
+}
+
+func TestCrawlURLUnknownStatus(t *testing.T) {
+	statuses := []string{"indexing", "completed"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			fmt.Fprint(w, `{"success":true,"id":"job-1"}`)
+			return
+		}
+		status := statuses[0]
+		statuses = statuses[1:]
+		fmt.Fprintf(w, `{"status":%q,"data":[{"markdown":"one"}]}`, status)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	response, err := app.CrawlURL("https://example.com", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", response.Status)
+	assert.Len(t, response.Data, 1)
+
+	statuses = []string{"failed"}
+	_, err = app.CrawlURL("https://example.com", nil, nil)
+	assert.EqualError(t, err, "crawl job failed or was stopped. Status: failed")
+}
+
+func TestCrawlURLWithRunningStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			fmt.Fprint(w, `{"success":true,"id":"job-1"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"indexing"}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithRunningStatuses("scraping"))
+	require.NoError(t, err)
+
+	response, err := app.CrawlURL("https://example.com", nil, nil)
+	assert.EqualError(t, err, "crawl job has an unexpected status: indexing")
+	require.NotNil(t, response)
+	assert.Equal(t, "indexing", response.Status)
+}
+
+func TestCrawlURLWithMaxJobWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			fmt.Fprint(w, `{"success":true,"id":"job-1"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"scraping","total":2,"completed":1}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithMaxJobWait(50*time.Millisecond))
+	require.NoError(t, err)
+
+	start := time.Now()
+	response, err := app.CrawlURL("https://example.com", nil, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	require.NotNil(t, response)
+	assert.Equal(t, "scraping", response.Status)
+}
+
+func TestCrawlURLWithProgress(t *testing.T) {
+	checks := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			fmt.Fprint(w, `{"success":true,"id":"job-1"}`)
+			return
+		}
+		checks++
+		if checks == 1 {
+			fmt.Fprint(w, `{"status":"scraping","total":2,"completed":1}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"completed","total":2,"completed":2,"data":[{"markdown":"one"},{"markdown":"two"}]}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	var progress []string
+	response, err := app.CrawlURLWithProgress("https://example.com", nil, nil, func(status *CrawlStatusResponse) {
+		progress = append(progress, fmt.Sprintf("%s %d/%d", status.Status, status.Completed, status.Total))
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"scraping 1/2", "completed 2/2"}, progress)
+	assert.Len(t, response.Data, 2)
+}
@@ -0,0 +1,92 @@
+// Package firecrawlprom provides a firecrawl.MetricsCollector that records Prometheus metrics. It is
+// a separate module so that the firecrawl module does not depend on the Prometheus client.
+package firecrawlprom
+
+import (
+	"strconv"
+	"time"
+
+	firecrawl "github.com/mendableai/firecrawl-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a firecrawl.MetricsCollector that records the requests made by a FirecrawlApp as
+// Prometheus metrics:
+//   - firecrawl_requests_total: a counter of HTTP attempts, including retries, by action and status code.
+//   - firecrawl_request_duration_seconds: a histogram of the duration of HTTP attempts, by action.
+//   - firecrawl_retries_total: a counter of retried attempts, by action.
+//
+// The status code label is "0" when an attempt failed before a response was received.
+type Collector struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+}
+
+var _ firecrawl.MetricsCollector = (*Collector)(nil)
+
+// NewCollector creates a new Collector and registers its metrics.
+//
+// Parameters:
+//   - registerer: The registerer of the metrics, e.g. a *prometheus.Registry or prometheus.DefaultRegisterer.
+//
+// Returns:
+//   - *Collector: A new instance of Collector, to pass to firecrawl.WithMetrics.
+//   - error: An error if the metrics cannot be registered, for example because they already are. No
+//     metric is left registered in that case.
+func NewCollector(registerer prometheus.Registerer) (*Collector, error) {
+	c := &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "firecrawl_requests_total",
+			Help: "Number of HTTP attempts made to the Firecrawl API, including retries.",
+		}, []string{"action", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "firecrawl_request_duration_seconds",
+			Help:    "Duration of HTTP attempts made to the Firecrawl API.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"action"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "firecrawl_retries_total",
+			Help: "Number of retried HTTP attempts made to the Firecrawl API.",
+		}, []string{"action"}),
+	}
+
+	var registered []prometheus.Collector
+	for _, collector := range []prometheus.Collector{c.requests, c.duration, c.retries} {
+		if err := registerer.Register(collector); err != nil {
+			for _, r := range registered {
+				registerer.Unregister(r)
+			}
+			return nil, err
+		}
+		registered = append(registered, collector)
+	}
+	return c, nil
+}
+
+// WithMetrics registers the metrics of a new Collector and returns an option that records them.
+//
+// Parameters:
+//   - registerer: The registerer of the metrics, e.g. a *prometheus.Registry or prometheus.DefaultRegisterer.
+//
+// Returns:
+//   - firecrawl.AppOption: A functional option that sets the collector.
+//   - error: An error if the metrics cannot be registered.
+func WithMetrics(registerer prometheus.Registerer) (firecrawl.AppOption, error) {
+	c, err := NewCollector(registerer)
+	if err != nil {
+		return nil, err
+	}
+	return firecrawl.WithMetrics(c), nil
+}
+
+// ObserveRequest records an HTTP attempt.
+func (c *Collector) ObserveRequest(action string, statusCode int, duration time.Duration) {
+	c.requests.WithLabelValues(action, strconv.Itoa(statusCode)).Inc()
+	c.duration.WithLabelValues(action).Observe(duration.Seconds())
+}
+
+// ObserveRetry records a retried attempt.
+func (c *Collector) ObserveRetry(action string) {
+	c.retries.WithLabelValues(action).Inc()
+}
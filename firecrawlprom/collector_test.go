@@ -0,0 +1,69 @@
+package firecrawlprom
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	firecrawl "github.com/mendableai/firecrawl-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `{"error":"Bad Gateway"}`)
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"status":"completed","data":[]}`)
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewRegistry()
+	collector, err := NewCollector(registry)
+	require.NoError(t, err)
+
+	app, err := firecrawl.NewFirecrawlApp("fc-test", server.URL, firecrawl.WithMetrics(collector), firecrawl.WithStatusCheckRetries(3, 1))
+	require.NoError(t, err)
+
+	_, err = app.CheckCrawlStatus("job-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.requests.WithLabelValues("check crawl status", "502")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.requests.WithLabelValues("check crawl status", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(collector.retries.WithLabelValues("check crawl status")))
+	assert.Equal(t, 1, testutil.CollectAndCount(collector.duration))
+}
+
+func TestWithMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	option, err := WithMetrics(registry)
+	require.NoError(t, err)
+	assert.NotNil(t, option)
+
+	_, err = WithMetrics(registry)
+	assert.Error(t, err)
+}
+
+func TestNewCollectorRegistrationFailure(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	conflicting := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "firecrawl_retries_total",
+		Help: "Number of retried HTTP attempts made to the Firecrawl API.",
+	}, []string{"action"})
+	require.NoError(t, registry.Register(conflicting))
+
+	_, err := NewCollector(registry)
+	assert.Error(t, err)
+
+	require.True(t, registry.Unregister(conflicting))
+	_, err = NewCollector(registry)
+	assert.NoError(t, err)
+}
@@ -6,6 +6,8 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/joho/godotenv v1.5.1
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/net v0.33.0
+	golang.org/x/time v0.10.0
 )
 
 require (
@@ -0,0 +1,387 @@
+package firecrawl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// markdownLinkDestination matches the parenthesized destination of an inline link or image: a
+// "<...>" destination, or a bare one that may contain one level of balanced parentheses, such as
+// "https://en.wikipedia.org/wiki/Go_(programming_language)", followed by an optional title.
+const markdownLinkDestination = `\(\s*(?:<[^<>\n]*>|(?:[^()\s]|\([^()\s]*\))*)(?:\s+(?:"[^"]*"|'[^']*'|\([^()]*\)))?\s*\)`
+
+var (
+	markdownImagePattern         = regexp.MustCompile(`!\[([^\]]*)\]` + markdownLinkDestination)
+	markdownInlineLinkPattern    = regexp.MustCompile(`\[([^\]]*)\]` + markdownLinkDestination)
+	markdownReferenceLinkPattern = regexp.MustCompile(`\[([^\]]*)\]\[[^\]]*\]`)
+	markdownLinkDefinitionLine   = regexp.MustCompile(`(?m)^[ \t]{0,3}\[[^\]]+\]:[ \t]*\S+.*(?:\n|$)`)
+	markdownAutolinkPattern      = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	horizontalWhitespacePattern  = regexp.MustCompile(`[ \t]+`)
+	trailingWhitespacePattern    = regexp.MustCompile(`(?m)[ \t]+$`)
+	blankLinesPattern            = regexp.MustCompile(`\n{3,}`)
+	markdownLinkDefinition       = regexp.MustCompile(`(?m)^[ \t]{0,3}\[([^\]]+)\]:[ \t]*<?([^\s>]+)>?(?:[ \t]+("[^"]*"|'[^']*'|\([^)]*\)))?[ \t]*$`)
+	markdownReferenceLink        = regexp.MustCompile(`(!?)\[([^\]]*)\]\[([^\]]*)\]`)
+	tableDelimiterRowPattern     = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+	codeFencePattern             = regexp.MustCompile("^\\s*(```|~~~)")
+	headingPattern               = regexp.MustCompile(`^\s{0,3}#{1,6}(?:\s+|$)(.*?)(?:\s+#+)?\s*$`)
+	blockquotePattern            = regexp.MustCompile(`^\s{0,3}(?:>\s?)+`)
+	listMarkerPattern            = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?`)
+	horizontalRulePattern        = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	strongEmphasisPattern        = regexp.MustCompile(`\*\*([^*\n]+)\*\*|\b__([^_\n]+)__\b`)
+	emphasisPattern              = regexp.MustCompile(`(^|[^\p{L}\p{N}*])\*([^*\s](?:[^*\n]*[^*\s])?)\*|\b_([^_\n]+)_\b`)
+	strikethroughPattern         = regexp.MustCompile(`~~([^~\n]+)~~`)
+	inlineCodePattern            = regexp.MustCompile("`([^`\n]*)`")
+	markdownEscapePattern        = regexp.MustCompile("\\\\([\\\\`*_{}\\[\\]()#+\\-.!|>~])")
+)
+
+// StripLinks removes link markup from markdown, keeping only the link text.
+// Images are replaced by their alt text, reference-style link definitions are dropped
+// and autolinks are reduced to their bare URL.
+//
+// Parameters:
+//   - markdown: The markdown to strip links from.
+//
+// Returns:
+//   - string: The markdown without link markup.
+func StripLinks(markdown string) string {
+	markdown = markdownLinkDefinitionLine.ReplaceAllString(markdown, "")
+	markdown = markdownImagePattern.ReplaceAllString(markdown, "$1")
+	markdown = markdownInlineLinkPattern.ReplaceAllString(markdown, "$1")
+	markdown = markdownReferenceLinkPattern.ReplaceAllString(markdown, "$1")
+	markdown = markdownAutolinkPattern.ReplaceAllString(markdown, "$1")
+	return markdown
+}
+
+// CollapseWhitespace normalizes whitespace in markdown. Runs of spaces and tabs are
+// collapsed to a single space, trailing whitespace is removed from each line and
+// consecutive blank lines are collapsed to one.
+//
+// Parameters:
+//   - markdown: The markdown to normalize.
+//
+// Returns:
+//   - string: The markdown with collapsed whitespace.
+func CollapseWhitespace(markdown string) string {
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+	markdown = horizontalWhitespacePattern.ReplaceAllString(markdown, " ")
+	markdown = trailingWhitespacePattern.ReplaceAllString(markdown, "")
+	markdown = blankLinesPattern.ReplaceAllString(markdown, "\n\n")
+	return strings.TrimSpace(markdown)
+}
+
+// InlineLinks rewrites reference-style links and images in markdown as inline ones, so the
+// markdown uses a single link style; the API has no option to choose the style it returns.
+// "[text][label]" and "[text][]" become "[text](url)" using the matching "[label]: url"
+// definition, which is then removed. References without a definition, and definitions that
+// are no longer referenced, are left unchanged.
+//
+// Parameters:
+//   - markdown: The markdown to rewrite.
+//
+// Returns:
+//   - string: The markdown with inline links.
+func InlineLinks(markdown string) string {
+	type definition struct {
+		destination string
+		title       string
+	}
+	definitions := make(map[string]definition)
+	for _, match := range markdownLinkDefinition.FindAllStringSubmatch(markdown, -1) {
+		label := strings.ToLower(strings.Join(strings.Fields(match[1]), " "))
+		if _, ok := definitions[label]; !ok {
+			definitions[label] = definition{destination: match[2], title: match[3]}
+		}
+	}
+	if len(definitions) == 0 {
+		return markdown
+	}
+
+	used := make(map[string]bool)
+	markdown = markdownReferenceLink.ReplaceAllStringFunc(markdown, func(link string) string {
+		match := markdownReferenceLink.FindStringSubmatch(link)
+		label := match[3]
+		if label == "" {
+			label = match[2]
+		}
+		label = strings.ToLower(strings.Join(strings.Fields(label), " "))
+		def, ok := definitions[label]
+		if !ok {
+			return link
+		}
+		used[label] = true
+		if def.title != "" {
+			return fmt.Sprintf("%s[%s](%s %s)", match[1], match[2], def.destination, def.title)
+		}
+		return fmt.Sprintf("%s[%s](%s)", match[1], match[2], def.destination)
+	})
+
+	return markdownLinkDefinitionLine.ReplaceAllStringFunc(markdown, func(line string) string {
+		match := markdownLinkDefinition.FindStringSubmatch(strings.TrimRight(line, "\n"))
+		if match == nil || !used[strings.ToLower(strings.Join(strings.Fields(match[1]), " "))] {
+			return line
+		}
+		return ""
+	})
+}
+
+// StripMarkdown converts markdown to plain text. Headings, emphasis, inline code, blockquote and
+// list markers, horizontal rules and code fences are removed, links and images are reduced to their
+// text as with StripLinks, table rows become their cells separated by spaces, backslash escapes are
+// resolved and whitespace is collapsed as with CollapseWhitespace. The content of code blocks is kept
+// as is.
+//
+// Parameters:
+//   - markdown: The markdown to convert.
+//
+// Returns:
+//   - string: The plain text.
+func StripMarkdown(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(StripLinks(markdown), "\r\n", "\n"), "\n")
+
+	var text strings.Builder
+	inCode := false
+	for _, line := range lines {
+		if codeFencePattern.MatchString(line) {
+			inCode = !inCode
+			text.WriteString("\n")
+			continue
+		}
+		if !inCode {
+			if tableDelimiterRowPattern.MatchString(strings.TrimSpace(line)) {
+				continue
+			}
+			line = stripMarkdownLine(line)
+		}
+		text.WriteString(line)
+		text.WriteString("\n")
+	}
+	return CollapseWhitespace(text.String())
+}
+
+// escapedRuneBase is the start of the private use runes that stand in for escaped characters while
+// markdown formatting is removed.
+const escapedRuneBase = '\uE000'
+
+// stripMarkdownLine removes the markdown formatting from a line outside of a code block.
+func stripMarkdownLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	if horizontalRulePattern.MatchString(line) {
+		return ""
+	}
+	if strings.HasPrefix(trimmed, "|") {
+		line = strings.Join(splitTableRow(trimmed), " ")
+	}
+
+	// Escaped characters are swapped for private use runes so they are not taken for formatting.
+	line = markdownEscapePattern.ReplaceAllStringFunc(line, func(escape string) string {
+		return string(escapedRuneBase + rune(escape[1]))
+	})
+	line = blockquotePattern.ReplaceAllString(line, "")
+	if match := headingPattern.FindStringSubmatch(line); match != nil {
+		line = match[1]
+	}
+	line = listMarkerPattern.ReplaceAllString(line, "$1")
+	line = inlineCodePattern.ReplaceAllString(line, "$1")
+	line = strongEmphasisPattern.ReplaceAllString(line, "$1$2")
+	line = emphasisPattern.ReplaceAllString(line, "$1$2$3")
+	line = strikethroughPattern.ReplaceAllString(line, "$1")
+	return strings.Map(func(r rune) rune {
+		if r >= escapedRuneBase && r < escapedRuneBase+0x80 {
+			return r - escapedRuneBase
+		}
+		return r
+	}, line)
+}
+
+// ChunkMarkdown splits markdown into chunks of at most maxChars characters (runes), for pipelines that
+// need bounded pieces of a long page. Chunks are cut at headings first, so each section stays whole
+// when it fits, and consecutive sections that fit together are packed into one chunk. Sections that
+// are too long are cut between paragraphs, then between lines, and a line that is still too long is
+// cut at maxChars. Headings and blank lines inside fenced code blocks are not cut at.
+//
+// Parameters:
+//   - markdown: The markdown to split.
+//   - maxChars: The maximum number of characters of a chunk. Zero or a negative value returns the
+//     whole markdown as a single chunk.
+//
+// Returns:
+//   - []string: The chunks, in order, or nil if the markdown is empty.
+func ChunkMarkdown(markdown string, maxChars int) []string {
+	markdown = strings.TrimSpace(strings.ReplaceAll(markdown, "\r\n", "\n"))
+	if markdown == "" {
+		return nil
+	}
+	if maxChars <= 0 {
+		return []string{markdown}
+	}
+	return chunkText(markdown, maxChars, 0)
+}
+
+// markdownSplitters split markdown into ever smaller pieces, with the separator used to join them
+// back, in the order ChunkMarkdown tries them.
+var markdownSplitters = []struct {
+	split     func(string) []string
+	separator string
+}{
+	{split: splitMarkdownSections, separator: "\n\n"},
+	{split: splitMarkdownParagraphs, separator: "\n\n"},
+	{split: func(text string) []string { return strings.Split(text, "\n") }, separator: "\n"},
+}
+
+// chunkText splits text into chunks of at most maxChars runes with the markdown splitters starting
+// at level, packing consecutive pieces that fit together into one chunk.
+func chunkText(text string, maxChars, level int) []string {
+	if utf8.RuneCountInString(text) <= maxChars {
+		return []string{text}
+	}
+	if level == len(markdownSplitters) {
+		var chunks []string
+		for runes := []rune(text); len(runes) > 0; {
+			n := min(maxChars, len(runes))
+			chunks = append(chunks, string(runes[:n]))
+			runes = runes[n:]
+		}
+		return chunks
+	}
+
+	splitter := markdownSplitters[level]
+	var chunks []string
+	current := ""
+	for _, piece := range splitter.split(text) {
+		// Leading whitespace is kept, as it can be the indentation of code.
+		piece = strings.TrimRightFunc(piece, unicode.IsSpace)
+		if strings.TrimSpace(piece) == "" {
+			continue
+		}
+		for _, part := range chunkText(piece, maxChars, level+1) {
+			if current == "" {
+				current = part
+			} else if candidate := current + splitter.separator + part; utf8.RuneCountInString(candidate) <= maxChars {
+				current = candidate
+			} else {
+				chunks = append(chunks, current)
+				current = part
+			}
+		}
+	}
+	if current != "" {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// splitMarkdownSections splits markdown before every heading outside of fenced code blocks.
+func splitMarkdownSections(markdown string) []string {
+	return splitMarkdownLines(markdown, func(line string, section []string) bool {
+		return len(section) > 0 && headingPattern.MatchString(line)
+	}, false)
+}
+
+// splitMarkdownParagraphs splits markdown at blank lines outside of fenced code blocks.
+func splitMarkdownParagraphs(markdown string) []string {
+	return splitMarkdownLines(markdown, func(line string, _ []string) bool {
+		return strings.TrimSpace(line) == ""
+	}, true)
+}
+
+// splitMarkdownLines splits markdown into pieces of lines, starting a new piece at every line
+// outside of fenced code blocks for which startsPiece returns true. If dropSplitLine is set, that
+// line is not kept.
+func splitMarkdownLines(markdown string, startsPiece func(line string, piece []string) bool, dropSplitLine bool) []string {
+	var pieces []string
+	var piece []string
+	inCode := false
+	for _, line := range strings.Split(markdown, "\n") {
+		if !inCode && startsPiece(line, piece) {
+			if len(piece) > 0 {
+				pieces = append(pieces, strings.Join(piece, "\n"))
+			}
+			piece = nil
+			if dropSplitLine {
+				continue
+			}
+		}
+		if codeFencePattern.MatchString(line) {
+			inCode = !inCode
+		}
+		piece = append(piece, line)
+	}
+	if len(piece) > 0 {
+		pieces = append(pieces, strings.Join(piece, "\n"))
+	}
+	return pieces
+}
+
+// MarkdownTable represents a table parsed from markdown.
+type MarkdownTable struct {
+	Header []string   `json:"header"`
+	Rows   [][]string `json:"rows"`
+}
+
+// ParseMarkdownTables extracts the pipe tables from markdown. Cells are trimmed and escaped
+// pipes ("\\|") are unescaped; inline markup inside cells is kept as is. Rows with fewer cells
+// than the header are padded with empty cells and extra cells are dropped.
+//
+// Parameters:
+//   - markdown: The markdown to extract tables from.
+//
+// Returns:
+//   - []MarkdownTable: The tables, in the order they appear in the markdown.
+func ParseMarkdownTables(markdown string) []MarkdownTable {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	var tables []MarkdownTable
+	for i := 0; i+1 < len(lines); i++ {
+		header := strings.TrimSpace(lines[i])
+		delimiter := strings.TrimSpace(lines[i+1])
+		if !strings.Contains(header, "|") || !tableDelimiterRowPattern.MatchString(delimiter) {
+			continue
+		}
+
+		table := MarkdownTable{Header: splitTableRow(header)}
+		if len(table.Header) != len(splitTableRow(delimiter)) {
+			continue
+		}
+
+		i += 2
+		for ; i < len(lines); i++ {
+			row := strings.TrimSpace(lines[i])
+			if row == "" || !strings.Contains(row, "|") {
+				break
+			}
+			cells := splitTableRow(row)
+			normalized := make([]string, len(table.Header))
+			copy(normalized, cells)
+			table.Rows = append(table.Rows, normalized)
+		}
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// splitTableRow splits a markdown table row into its trimmed cells.
+func splitTableRow(row string) []string {
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, "\\|") {
+		row = strings.TrimSuffix(row, "|")
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
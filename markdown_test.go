@@ -0,0 +1,133 @@
+package firecrawl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripLinks(t *testing.T) {
+	markdown := "See [the docs](https://example.com/docs \"Docs\") and [the guide][guide].\n" +
+		"[![Logo](https://example.com/logo.png)](https://example.com)\n" +
+		"Contact <mailto:team@example.com> or <https://example.com>.\n" +
+		"\n" +
+		"[guide]: https://example.com/guide\n"
+
+	assert.Equal(t,
+		"See the docs and the guide.\nLogo\nContact mailto:team@example.com or https://example.com.\n\n",
+		StripLinks(markdown),
+	)
+}
+
+func TestStripLinksDestinations(t *testing.T) {
+	assert.Equal(t, "x", StripLinks("[x](https://en.wikipedia.org/wiki/Go_(programming_language))"))
+	assert.Equal(t, "Gopher", StripLinks("![Gopher](https://example.com/go_(mascot).png 'The (Go) gopher')"))
+	assert.Equal(t, "a spaced link.", StripLinks("a [spaced link](<https://example.com/a page>)."))
+}
+
+func TestCollapseWhitespace(t *testing.T) {
+	markdown := "  # Title  \r\n\r\n\r\n\r\nSome\t\ttext   here.  \n\n\n- item\n"
+
+	assert.Equal(t, "# Title\n\nSome text here.\n\n- item", CollapseWhitespace(markdown))
+}
+
+func TestStripMarkdown(t *testing.T) {
+	markdown := "# Getting *Started* #\n\n" +
+		"Read **the [guide](https://example.com/guide)** and use `snake_case` names, not ~~camelCase~~.\n\n" +
+		"> Quoted _advice_.\n\n" +
+		"- [x] First step\n" +
+		"2. Second \\*step\\*\n\n" +
+		"---\n\n" +
+		"| Name | Value |\n" +
+		"|------|-------|\n" +
+		"| a | 1 |\n\n" +
+		"```go\n" +
+		"x := *p\n" +
+		"```\n" +
+		"![Diagram](https://example.com/diagram.png)\n"
+
+	assert.Equal(t,
+		"Getting Started\n\nRead the guide and use snake_case names, not camelCase.\n\nQuoted advice.\n\n"+
+			"First step\nSecond *step*\n\nName Value\na 1\n\nx := *p\n\nDiagram",
+		StripMarkdown(markdown),
+	)
+}
+
+func TestStripMarkdownEmphasisFlanking(t *testing.T) {
+	assert.Equal(t, "2*3*4", StripMarkdown("2*3*4"))
+	assert.Equal(t, "a * b * c", StripMarkdown("a * b * c"))
+	assert.Equal(t, "Use it (carefully) now", StripMarkdown("Use *it* (*carefully*) now"))
+}
+
+func TestChunkMarkdown(t *testing.T) {
+	markdown := "# Intro\n\nShort intro.\n\n" +
+		"## Setup\n\nInstall it.\n\n" +
+		"## Usage\n\nFirst paragraph about usage.\n\nSecond paragraph about usage.\n\n" +
+		"```sh\n# not a heading\n\nrun --all\n```\n"
+
+	chunks := ChunkMarkdown(markdown, 40)
+	assert.Equal(t, []string{
+		"# Intro\n\nShort intro.",
+		"## Setup\n\nInstall it.",
+		"## Usage\n\nFirst paragraph about usage.",
+		"Second paragraph about usage.",
+		"```sh\n# not a heading\n\nrun --all\n```",
+	}, chunks)
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, len([]rune(chunk)), 40)
+	}
+
+	assert.Equal(t, []string{"# Intro\n\nShort intro.\n\n## Setup\n\nInstall it."}, ChunkMarkdown("# Intro\n\nShort intro.\n\n## Setup\n\nInstall it.", 100))
+	assert.Equal(t, []string{"ääää", "ää"}, ChunkMarkdown("ääääää", 4))
+	assert.Equal(t, []string{"whole"}, ChunkMarkdown(" whole\n", 0))
+	assert.Nil(t, ChunkMarkdown("  \n", 10))
+}
+
+func TestParseMarkdownTables(t *testing.T) {
+	markdown := "# Results\n\n" +
+		"| Quarter | Revenue | Note |\n" +
+		"|:--------|--------:|------|\n" +
+		"| Q1 | $1,200 | a \\| b |\n" +
+		"| Q2 | $1,450 |\n" +
+		"\n" +
+		"Some text | with a pipe\n" +
+		"\n" +
+		"Name | Value\n" +
+		"--- | ---\n" +
+		"x | 1\n"
+
+	tables := ParseMarkdownTables(markdown)
+
+	assert.Equal(t, []MarkdownTable{
+		{
+			Header: []string{"Quarter", "Revenue", "Note"},
+			Rows: [][]string{
+				{"Q1", "$1,200", "a | b"},
+				{"Q2", "$1,450", ""},
+			},
+		},
+		{
+			Header: []string{"Name", "Value"},
+			Rows:   [][]string{{"x", "1"}},
+		},
+	}, tables)
+}
+
+func TestInlineLinks(t *testing.T) {
+	markdown := "See [the docs][docs], [the Guide][] and ![logo][img].\n" +
+		"Unknown [ref][missing] stays.\n" +
+		"\n" +
+		"[docs]: https://example.com/docs \"Docs\"\n" +
+		"[the guide]: <https://example.com/guide>\n" +
+		"[img]: https://example.com/logo.png\n" +
+		"[unused]: https://example.com/unused\n"
+
+	assert.Equal(t,
+		"See [the docs](https://example.com/docs \"Docs\"), [the Guide](https://example.com/guide) and ![logo](https://example.com/logo.png).\n"+
+			"Unknown [ref][missing] stays.\n"+
+			"\n"+
+			"[unused]: https://example.com/unused\n",
+		InlineLinks(markdown),
+	)
+	assert.Equal(t, "[inline](https://example.com)", InlineLinks("[inline](https://example.com)"))
+}
@@ -0,0 +1,14 @@
+package firecrawl
+
+import "time"
+
+// MetricsCollector receives metrics about the requests made by a FirecrawlApp. Implementations
+// can forward them to a metrics system such as Prometheus, and must be safe for concurrent use.
+type MetricsCollector interface {
+	// ObserveRequest is called after every HTTP attempt, including retries. The status code is 0
+	// when the attempt failed before a response was received.
+	ObserveRequest(action string, statusCode int, duration time.Duration)
+
+	// ObserveRetry is called before every retried attempt.
+	ObserveRetry(action string)
+}
@@ -0,0 +1,420 @@
+package firecrawl
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// AppOption is a functional option type for FirecrawlApp.
+type AppOption func(*FirecrawlApp)
+
+// WithMaxResponseSize sets the maximum size of a response body read from the Firecrawl API.
+// Requests whose response exceeds the limit fail with an error instead of buffering the whole body.
+//
+// Parameters:
+//   - maxBytes: The maximum response size in bytes. Zero or a negative value disables the limit.
+//
+// Returns:
+//   - AppOption: A functional option that sets the maximum response size.
+func WithMaxResponseSize(maxBytes int64) AppOption {
+	return func(app *FirecrawlApp) {
+		app.maxResponseSize = maxBytes
+	}
+}
+
+// WithStatusCheckRetries sets the retry policy used when checking the status of crawl jobs,
+// independently of the policy used to submit them. The defaults are 3 retries and a 500ms backoff.
+//
+// Parameters:
+//   - retries: The number of attempts made for each status check.
+//   - backoff: The base backoff interval (in milliseconds), doubled after each attempt.
+//
+// Returns:
+//   - AppOption: A functional option that sets the status check retry policy.
+func WithStatusCheckRetries(retries int, backoff int) AppOption {
+	return func(app *FirecrawlApp) {
+		app.statusCheckRetries = retries
+		app.statusCheckBackoff = backoff
+	}
+}
+
+// WithStatusCheckJitter randomizes each status check backoff interval between half and all
+// of its computed value, so concurrent monitors do not retry in lockstep.
+//
+// Parameters:
+//   - jitter: Whether to apply jitter to status check backoff intervals.
+//
+// Returns:
+//   - AppOption: A functional option that enables jitter for status checks.
+func WithStatusCheckJitter(jitter bool) AppOption {
+	return func(app *FirecrawlApp) {
+		app.statusCheckJitter = jitter
+	}
+}
+
+// WithMaxBackoff caps the interval waited between retries, which otherwise doubles after each
+// attempt. The cap applies before jitter. By default the interval is not capped.
+//
+// Parameters:
+//   - maxBackoff: The maximum backoff interval. Zero or a negative value disables the cap.
+//
+// Returns:
+//   - AppOption: A functional option that sets the maximum backoff interval.
+func WithMaxBackoff(maxBackoff time.Duration) AppOption {
+	return func(app *FirecrawlApp) {
+		app.maxBackoff = maxBackoff
+	}
+}
+
+// WithMaxStatusPages sets the maximum number of pages of a completed crawl or batch scrape status
+// that are fetched while waiting for a job, guarding against a status whose next pages never end.
+// The default is 1000 pages.
+//
+// Parameters:
+//   - maxPages: The maximum number of pages. Zero or a negative value restores the default.
+//
+// Returns:
+//   - AppOption: A functional option that sets the maximum number of status pages.
+func WithMaxStatusPages(maxPages int) AppOption {
+	return func(app *FirecrawlApp) {
+		app.maxStatusPages = maxPages
+	}
+}
+
+// WithEmptyResultRetries sets how often a crawl or batch scrape job that is reported as completed
+// without any data is polled again before failing, for jobs whose data is not queryable yet when
+// they complete. The defaults are 3 retries, waiting the job's poll interval between them.
+//
+// Parameters:
+//   - retries: The number of times the status is checked again. Zero fails on the first empty result.
+//   - delay: The interval between checks. Zero or a negative value uses the poll interval.
+//
+// Returns:
+//   - AppOption: A functional option that sets the empty result retry policy.
+func WithEmptyResultRetries(retries int, delay time.Duration) AppOption {
+	return func(app *FirecrawlApp) {
+		app.emptyResultRetries = &retries
+		app.emptyResultDelay = delay
+	}
+}
+
+// WithMaxJobWait limits how long crawls and batch scrapes are monitored until they complete. Once
+// the limit is reached, monitoring stops and the last status received is returned along with
+// context.DeadlineExceeded. It applies to every entry point that waits for a job, including those
+// without a context such as CrawlURL; a deadline set on a caller's context still applies too.
+//
+// Parameters:
+//   - maxWait: The maximum time to monitor a job. Zero or a negative value monitors it until it ends.
+//
+// Returns:
+//   - AppOption: A functional option that sets the maximum job wait.
+func WithMaxJobWait(maxWait time.Duration) AppOption {
+	return func(app *FirecrawlApp) {
+		app.maxJobWait = maxWait
+	}
+}
+
+// WithRunningStatuses sets the job statuses that mean a crawl or batch scrape is still in progress.
+// Monitoring a job that reports any other status than these, "completed", "failed" or "cancelled"
+// then ends with an error instead of polling on. By default every other status is treated as in
+// progress, so that statuses added to the API later do not break monitoring.
+//
+// Parameters:
+//   - statuses: The in-progress statuses, e.g. "scraping". None restores the default.
+//
+// Returns:
+//   - AppOption: A functional option that sets the in-progress job statuses.
+func WithRunningStatuses(statuses ...string) AppOption {
+	return func(app *FirecrawlApp) {
+		app.runningStatuses = nil
+		if len(statuses) > 0 {
+			app.runningStatuses = make(map[string]bool, len(statuses))
+			for _, status := range statuses {
+				app.runningStatuses[status] = true
+			}
+		}
+	}
+}
+
+// WithDebugRequestBodies records the JSON body of every request rejected by the Firecrawl API in the
+// RequestBody of the returned *FirecrawlError, to see exactly what was sent. The body includes the
+// headers sent to scraped pages, such as credentials set with ScrapeParams.SetBasicAuth, so avoid
+// logging it in production.
+//
+// Parameters:
+//   - enabled: Whether to record request bodies in API errors.
+//
+// Returns:
+//   - AppOption: A functional option that enables request body debugging.
+func WithDebugRequestBodies(enabled bool) AppOption {
+	return func(app *FirecrawlApp) {
+		app.debugRequestBodies = enabled
+	}
+}
+
+// WithRequestCompression gzip-compresses the JSON body of requests to the Firecrawl API that are at
+// least threshold bytes long, and marks them with a "Content-Encoding: gzip" header. This shrinks
+// large submissions, such as batch scrapes of thousands of URLs. Only enable it against an API
+// server that accepts gzip-encoded request bodies; one that does not rejects the requests.
+//
+// Parameters:
+//   - threshold: The minimum body size in bytes to compress. Zero or a negative value disables compression.
+//
+// Returns:
+//   - AppOption: A functional option that enables request body compression.
+func WithRequestCompression(threshold int) AppOption {
+	return func(app *FirecrawlApp) {
+		app.compressionThreshold = threshold
+	}
+}
+
+// WithDefaultOnlyMainContent sets the OnlyMainContent value sent with every scrape, batch scrape
+// and crawl whose ScrapeParams leave it unset; for a crawl, it applies to the scrapeOptions of
+// CrawlParams. A value set on the ScrapeParams of a request always takes precedence. Without this
+// option the API's own default applies.
+//
+// Parameters:
+//   - onlyMainContent: Whether scrapes return only the main content of the page by default.
+//
+// Returns:
+//   - AppOption: A functional option that sets the default OnlyMainContent value.
+func WithDefaultOnlyMainContent(onlyMainContent bool) AppOption {
+	return func(app *FirecrawlApp) {
+		app.defaultOnlyMainContent = &onlyMainContent
+	}
+}
+
+// WithRateLimit limits the rate of requests made to the Firecrawl API. Requests, including
+// retries, block until the limit allows them to be sent.
+//
+// Parameters:
+//   - rps: The number of requests allowed per second. Zero or a negative value disables the limit.
+//   - burst: The maximum number of requests allowed at once. Values below 1 are treated as 1.
+//
+// Returns:
+//   - AppOption: A functional option that sets the rate limit.
+func WithRateLimit(rps float64, burst int) AppOption {
+	return func(app *FirecrawlApp) {
+		if rps <= 0 {
+			app.rateLimiter = nil
+			return
+		}
+		app.rateLimiter = rate.NewLimiter(rate.Limit(rps), max(burst, 1))
+	}
+}
+
+// WithMetrics records metrics about every request made to the Firecrawl API, including retries,
+// with the given collector.
+//
+// Parameters:
+//   - collector: The collector that receives request metrics.
+//
+// Returns:
+//   - AppOption: A functional option that sets the metrics collector.
+func WithMetrics(collector MetricsCollector) AppOption {
+	return func(app *FirecrawlApp) {
+		app.metrics = collector
+	}
+}
+
+// WithUseNumber decodes numbers in untyped response values, such as the values returned by
+// "executeJavascript" actions, as json.Number instead of float64, so large integers keep their
+// exact value. Typed fields such as CreditsUsed are always decoded as integers.
+//
+// Returns:
+//   - AppOption: A functional option that enables json.Number decoding.
+func WithUseNumber() AppOption {
+	return func(app *FirecrawlApp) {
+		app.useNumber = true
+	}
+}
+
+// WithOrigin tags every request made to the Firecrawl API with the given origin in the X-Origin header,
+// so traffic from different services sharing an API key can be told apart.
+//
+// Parameters:
+//   - origin: The origin tag, e.g. the name of the calling service.
+//
+// Returns:
+//   - AppOption: A functional option that sets the origin tag.
+func WithOrigin(origin string) AppOption {
+	return func(app *FirecrawlApp) {
+		app.origin = origin
+	}
+}
+
+// WithAccept sets the Accept header sent with every request to the Firecrawl API, which is
+// "application/json" by default, for proxies or gateways that negotiate content based on it.
+// Responses are still decoded as JSON, so the value must accept it.
+//
+// Parameters:
+//   - accept: The Accept header value, e.g. "application/json, application/problem+json".
+//
+// Returns:
+//   - AppOption: A functional option that sets the Accept header.
+func WithAccept(accept string) AppOption {
+	return func(app *FirecrawlApp) {
+		app.accept = accept
+	}
+}
+
+// WithRetryPolicy sets the function deciding whether a request attempt is retried, within the
+// retry count of the operation. The response body has already been read when the policy is called
+// and can be read again. By default only 502 Bad Gateway responses are retried.
+//
+// Parameters:
+//   - policy: A function returning true if the attempt should be retried. resp is nil when err is set.
+//
+// Returns:
+//   - AppOption: A functional option that sets the retry policy.
+func WithRetryPolicy(policy func(resp *http.Response, err error) bool) AppOption {
+	return func(app *FirecrawlApp) {
+		app.retryPolicy = policy
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to make requests to the Firecrawl API, replacing the
+// default client with a 60 second timeout.
+//
+// Parameters:
+//   - client: The HTTP client to use.
+//
+// Returns:
+//   - AppOption: A functional option that sets the HTTP client.
+func WithHTTPClient(client *http.Client) AppOption {
+	return func(app *FirecrawlApp) {
+		app.Client = client
+	}
+}
+
+// WithTransport sets the transport of the app's HTTP client. This is useful in tests to record and
+// replay interactions with the Firecrawl API, or to serve canned responses, without network access.
+//
+// Parameters:
+//   - transport: The transport used to make HTTP requests.
+//
+// Returns:
+//   - AppOption: A functional option that sets the HTTP transport.
+func WithTransport(transport http.RoundTripper) AppOption {
+	return func(app *FirecrawlApp) {
+		client := *app.httpClient()
+		client.Transport = transport
+		app.Client = &client
+	}
+}
+
+// WithConnectionPool tunes the connection pool of the app's HTTP transport. The default transport
+// keeps only 2 idle connections per host, which limits connection reuse when many requests are made
+// concurrently. The option clones the client's *http.Transport, or http.DefaultTransport if none is
+// set; a custom RoundTripper set with WithTransport is left unchanged.
+//
+// Parameters:
+//   - maxIdleConns: The maximum number of idle connections across all hosts. Zero means no limit.
+//   - maxIdleConnsPerHost: The maximum number of idle connections kept per host.
+//   - idleConnTimeout: How long an idle connection is kept before it is closed. Zero means no limit.
+//
+// Returns:
+//   - AppOption: A functional option that tunes the HTTP connection pool.
+func WithConnectionPool(maxIdleConns, maxIdleConnsPerHost int, idleConnTimeout time.Duration) AppOption {
+	return func(app *FirecrawlApp) {
+		tuneTransport(app, func(transport *http.Transport) {
+			transport.MaxIdleConns = maxIdleConns
+			transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+			transport.IdleConnTimeout = idleConnTimeout
+		})
+	}
+}
+
+// WithHTTP2 sets whether requests to the Firecrawl API may use HTTP/2. HTTP/2 is enabled by
+// default: the default transport negotiates it with servers that support it, such as the hosted
+// API, and multiplexes concurrent requests over a single connection. Disabling it makes the client
+// open one HTTP/1.1 connection per concurrent request, which can help to debug proxies that mishandle
+// HTTP/2. Like WithConnectionPool, it leaves a custom RoundTripper set with WithTransport unchanged.
+//
+// Parameters:
+//   - enabled: Whether HTTP/2 may be used.
+//
+// Returns:
+//   - AppOption: A functional option that enables or disables HTTP/2.
+func WithHTTP2(enabled bool) AppOption {
+	return func(app *FirecrawlApp) {
+		tuneTransport(app, func(transport *http.Transport) {
+			transport.ForceAttemptHTTP2 = enabled
+			if enabled {
+				return
+			}
+			transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+			if transport.TLSClientConfig != nil {
+				// Stop offering HTTP/2 during the TLS handshake, or the server may select it.
+				var protos []string
+				for _, proto := range transport.TLSClientConfig.NextProtos {
+					if proto != "h2" {
+						protos = append(protos, proto)
+					}
+				}
+				transport.TLSClientConfig.NextProtos = protos
+			}
+		})
+	}
+}
+
+// WithKeepAlive sets the interval of the TCP keep-alive probes sent on idle connections to the
+// Firecrawl API, which detect dead connections and keep load balancers from dropping idle ones
+// during long crawls. The default transport probes every 30 seconds. Like WithConnectionPool, it
+// leaves a custom RoundTripper set with WithTransport unchanged.
+//
+// Parameters:
+//   - interval: The interval between keep-alive probes. A negative value disables keep-alive probes.
+//
+// Returns:
+//   - AppOption: A functional option that sets the TCP keep-alive interval.
+func WithKeepAlive(interval time.Duration) AppOption {
+	return func(app *FirecrawlApp) {
+		tuneTransport(app, func(transport *http.Transport) {
+			dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: interval}
+			transport.DialContext = dialer.DialContext
+		})
+	}
+}
+
+// tuneTransport applies tune to a clone of the app client's *http.Transport, or of
+// http.DefaultTransport if none is set, and sets the clone on a copy of the client. Custom
+// RoundTripper implementations are left unchanged.
+//
+// Parameters:
+//   - app: The app whose transport to tune.
+//   - tune: The function that modifies the cloned transport.
+func tuneTransport(app *FirecrawlApp, tune func(*http.Transport)) {
+	client := *app.httpClient()
+	base, ok := client.Transport.(*http.Transport)
+	if client.Transport == nil {
+		base, ok = http.DefaultTransport.(*http.Transport)
+	}
+	if !ok {
+		return
+	}
+
+	transport := base.Clone()
+	tune(transport)
+
+	client.Transport = transport
+	app.Client = &client
+}
+
+// WithAuthScheme sets the scheme of the Authorization header sent with the API key, for gateways
+// that expect something other than the default "Bearer <key>".
+//
+// Parameters:
+//   - scheme: The authorization scheme, e.g. "Token". An empty scheme sends the bare API key.
+//
+// Returns:
+//   - AppOption: A functional option that sets the authorization scheme.
+func WithAuthScheme(scheme string) AppOption {
+	return func(app *FirecrawlApp) {
+		app.authScheme = &scheme
+	}
+}
@@ -0,0 +1,497 @@
+package firecrawl
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMaxResponseSize(t *testing.T) {
+	markdown := strings.Repeat("a", 1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"success":true,"data":{"markdown":%q}}`, markdown)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithMaxResponseSize(100))
+	require.NoError(t, err)
+
+	_, err = app.ScrapeURL("https://example.com", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "response body exceeds maximum size of 100 bytes")
+
+	app, err = NewFirecrawlApp("fc-test", server.URL, WithMaxResponseSize(4096))
+	require.NoError(t, err)
+
+	response, err := app.ScrapeURL("https://example.com", nil)
+	require.NoError(t, err)
+	assert.Equal(t, markdown, response.Markdown)
+}
+
+func TestWithStatusCheckRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts%3 != 0 {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `{"error":"Bad Gateway"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"scraping","total":10,"completed":2}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithStatusCheckRetries(3, 1), WithStatusCheckJitter(true))
+	require.NoError(t, err)
+
+	response, err := app.CheckCrawlStatus("job-1")
+	require.NoError(t, err)
+	assert.Equal(t, "scraping", response.Status)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	app, err = NewFirecrawlApp("fc-test", server.URL, WithStatusCheckRetries(1, 1))
+	require.NoError(t, err)
+
+	_, err = app.CheckCrawlStatus("job-1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Status code 502")
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithMaxBackoff(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{"error":"Bad Gateway"}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithStatusCheckRetries(4, 1000), WithMaxBackoff(10*time.Millisecond))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = app.CheckCrawlStatus("job-1")
+	assert.Error(t, err)
+	assert.Equal(t, 4, attempts)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+type recordingMetrics struct {
+	mu       sync.Mutex
+	requests []int
+	retries  int
+}
+
+func (m *recordingMetrics) ObserveRequest(action string, statusCode int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, statusCode)
+}
+
+func (m *recordingMetrics) ObserveRetry(action string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries++
+}
+
+func TestWithMetrics(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `{"error":"Bad Gateway"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"scraping"}`)
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithMetrics(metrics), WithStatusCheckRetries(3, 1))
+	require.NoError(t, err)
+
+	_, err = app.CheckCrawlStatus("job-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{http.StatusBadGateway, http.StatusOK}, metrics.requests)
+	assert.Equal(t, 1, metrics.retries)
+}
+
+func TestWithRateLimit(t *testing.T) {
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithRateLimit(20, 2))
+	require.NoError(t, err)
+	require.NotNil(t, app.rateLimiter)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(t, app.rateLimiter.AllowN(start, 1))
+	assert.True(t, app.rateLimiter.AllowN(start, 1))
+	assert.False(t, app.rateLimiter.AllowN(start, 1))
+	assert.False(t, app.rateLimiter.AllowN(start.Add(40*time.Millisecond), 1))
+	assert.True(t, app.rateLimiter.AllowN(start.Add(50*time.Millisecond), 1))
+
+	app, err = NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithRateLimit(20, 0), WithRateLimit(0, 1))
+	require.NoError(t, err)
+	assert.Nil(t, app.rateLimiter)
+}
+
+func TestWithRateLimitHonorsContext(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `{"success":true}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithRateLimit(0.001, 1))
+	require.NoError(t, err)
+
+	_, err = app.makeRequest(http.MethodGet, server.URL, nil, nil, "check crawl status")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = app.makeRequest(http.MethodGet, server.URL, nil, nil, "check crawl status", withContext(ctx))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestWithUseNumber(t *testing.T) {
+	body := []byte(`{"creditsUsed":9007199254740993}`)
+
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithUseNumber())
+	require.NoError(t, err)
+
+	var data map[string]any
+	require.NoError(t, app.unmarshalResponse(body, &data))
+	assert.Equal(t, json.Number("9007199254740993"), data["creditsUsed"])
+
+	app, err = NewFirecrawlApp("fc-test", "https://api.firecrawl.dev")
+	require.NoError(t, err)
+
+	require.NoError(t, app.unmarshalResponse(body, &data))
+	assert.IsType(t, float64(0), data["creditsUsed"])
+}
+
+func TestWithUseNumberScrapeJavascriptReturns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true,"data":{"markdown":"# Example","actions":{"javascriptReturns":[{"type":"number","value":9007199254740993},{"type":"object","value":{"id":9007199254740993}}]}}}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithUseNumber())
+	require.NoError(t, err)
+
+	doc, err := app.ScrapeURL("https://example.com", nil)
+	require.NoError(t, err)
+	require.NotNil(t, doc.Actions)
+	require.Len(t, doc.Actions.JavascriptReturns, 2)
+	assert.Equal(t, json.Number("9007199254740993"), doc.Actions.JavascriptReturns[0].Value)
+	assert.Equal(t, map[string]any{"id": json.Number("9007199254740993")}, doc.Actions.JavascriptReturns[1].Value)
+
+	app, err = NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+
+	doc, err = app.ScrapeURL("https://example.com", nil)
+	require.NoError(t, err)
+	assert.IsType(t, float64(0), doc.Actions.JavascriptReturns[0].Value)
+}
+
+func TestWithOrigin(t *testing.T) {
+	var origins []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origins = append(origins, r.Header.Get("X-Origin"))
+		fmt.Fprint(w, `{"success":true,"id":"job-1","status":"scraping","links":["https://example.com"]}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithOrigin("my-service"))
+	require.NoError(t, err)
+
+	_, err = app.AsyncCrawlURL("https://example.com", nil, nil)
+	require.NoError(t, err)
+	_, err = app.CheckCrawlStatus("job-1")
+	require.NoError(t, err)
+	_, err = app.MapURL("https://example.com", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"my-service", "my-service", "my-service"}, origins)
+}
+
+func TestWithAccept(t *testing.T) {
+	var accepts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accepts = append(accepts, r.Header.Get("Accept"))
+		fmt.Fprint(w, `{"success":true,"data":{"markdown":"# Example"}}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL)
+	require.NoError(t, err)
+	_, err = app.ScrapeURL("https://example.com", nil)
+	require.NoError(t, err)
+
+	app, err = NewFirecrawlApp("fc-test", server.URL, WithAccept("application/json, application/problem+json"))
+	require.NoError(t, err)
+	_, err = app.ScrapeURL("https://example.com", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"application/json", "application/json, application/problem+json"}, accepts)
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			fmt.Fprint(w, `{"success":false,"error":"upstream proxy error"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"scraping"}`)
+	}))
+	defer server.Close()
+
+	policy := func(resp *http.Response, err error) bool {
+		if err != nil {
+			return false
+		}
+		body, err := io.ReadAll(resp.Body)
+		return err == nil && strings.Contains(string(body), "upstream proxy error")
+	}
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithRetryPolicy(policy), WithStatusCheckRetries(3, 1))
+	require.NoError(t, err)
+
+	response, err := app.CheckCrawlStatus("job-1")
+	require.NoError(t, err)
+	assert.Equal(t, "scraping", response.Status)
+	assert.Equal(t, 3, attempts)
+
+	attempts = -10
+	_, err = app.CheckCrawlStatus("job-1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "all 3 retries exhausted: failed to check crawl status")
+	var firecrawlErr *FirecrawlError
+	require.ErrorAs(t, err, &firecrawlErr)
+	assert.Equal(t, http.StatusOK, firecrawlErr.StatusCode)
+
+	attempts = -10
+	_, err = app.makeRequest(http.MethodGet, server.URL+"/v1/crawl/job-1", nil, nil, "check crawl status")
+	require.ErrorAs(t, err, &firecrawlErr)
+	assert.Equal(t, http.StatusOK, firecrawlErr.StatusCode)
+	assert.NotContains(t, err.Error(), "retries exhausted")
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.firecrawl.dev/v1/scrape", req.URL.String())
+		assert.Equal(t, "Bearer fc-test", req.Header.Get("Authorization"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"success":true,"data":{"markdown":"# Replayed"}}`)),
+			Header:     make(http.Header),
+			Request:    req,
+		}, nil
+	})
+
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithTransport(transport))
+	require.NoError(t, err)
+	assert.Equal(t, 60*time.Second, app.Client.Timeout)
+
+	response, err := app.ScrapeURL("https://example.com", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "# Replayed", response.Markdown)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithHTTPClient(client))
+	require.NoError(t, err)
+	assert.Same(t, client, app.Client)
+}
+
+func TestTransportOptionsWithNilClient(t *testing.T) {
+	custom := roundTripperFunc(func(req *http.Request) (*http.Response, error) { return nil, nil })
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithHTTPClient(nil), WithTransport(custom))
+	require.NoError(t, err)
+	_, ok := app.Client.Transport.(roundTripperFunc)
+	assert.True(t, ok)
+
+	app, err = NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithHTTPClient(nil), WithConnectionPool(100, 50, 0))
+	require.NoError(t, err)
+	transport, ok := app.Client.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 50, transport.MaxIdleConnsPerHost)
+}
+
+func TestWithConnectionPool(t *testing.T) {
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithConnectionPool(100, 50, 30*time.Second))
+	require.NoError(t, err)
+
+	transport, ok := app.Client.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 100, transport.MaxIdleConns)
+	assert.Equal(t, 50, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 30*time.Second, transport.IdleConnTimeout)
+	assert.Equal(t, 60*time.Second, app.Client.Timeout)
+	assert.NotSame(t, http.DefaultTransport, app.Client.Transport)
+
+	custom := roundTripperFunc(func(req *http.Request) (*http.Response, error) { return nil, nil })
+	app, err = NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithTransport(custom), WithConnectionPool(100, 50, 0))
+	require.NoError(t, err)
+	_, ok = app.Client.Transport.(roundTripperFunc)
+	assert.True(t, ok)
+}
+
+func TestWithAuthScheme(t *testing.T) {
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer fc-test", app.prepareHeaders(nil)["Authorization"])
+
+	app, err = NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithAuthScheme("Token"))
+	require.NoError(t, err)
+	assert.Equal(t, "Token fc-test", app.prepareHeaders(nil)["Authorization"])
+
+	app, err = NewFirecrawlApp("fc-test", "https://api.firecrawl.dev", WithAuthScheme(""))
+	require.NoError(t, err)
+	assert.Equal(t, "fc-test", app.prepareHeaders(nil)["Authorization"])
+}
+
+func TestWithDefaultOnlyMainContent(t *testing.T) {
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		fmt.Fprint(w, `{"success":true,"id":"job","data":{"markdown":"# Example"}}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithDefaultOnlyMainContent(true))
+	require.NoError(t, err)
+
+	_, err = app.ScrapeURL("https://example.com", nil)
+	require.NoError(t, err)
+	_, err = app.ScrapeURL("https://example.com", &ScrapeParams{OnlyMainContent: ptr(false)})
+	require.NoError(t, err)
+	_, err = app.AsyncBatchScrapeURLs([]string{"https://example.com"}, nil, nil)
+	require.NoError(t, err)
+	_, err = app.AsyncCrawlURL("https://example.com", nil, nil)
+	require.NoError(t, err)
+	_, err = app.AsyncCrawlURL("https://example.com", &CrawlParams{ScrapeOptions: ScrapeParams{Formats: []string{"markdown"}, OnlyMainContent: ptr(false)}}, nil)
+	require.NoError(t, err)
+
+	require.Len(t, bodies, 5)
+	assert.Equal(t, true, bodies[0]["onlyMainContent"])
+	assert.Equal(t, false, bodies[1]["onlyMainContent"])
+	assert.Equal(t, true, bodies[2]["onlyMainContent"])
+	assert.Equal(t, map[string]any{"onlyMainContent": true}, bodies[3]["scrapeOptions"])
+	assert.Equal(t, false, bodies[4]["scrapeOptions"].(map[string]any)["onlyMainContent"])
+}
+
+func TestWithDefaultOnlyMainContentCrawlURL(t *testing.T) {
+	var scrapeOptions any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			scrapeOptions = body["scrapeOptions"]
+			fmt.Fprint(w, `{"success":true,"id":"job"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"completed","data":[]}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithDefaultOnlyMainContent(false))
+	require.NoError(t, err)
+
+	_, err = app.CrawlURL("https://example.com", &CrawlParams{Limit: ptr(1)}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"onlyMainContent": false}, scrapeOptions)
+}
+
+func TestWithRequestCompression(t *testing.T) {
+	var encodings []string
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		var reader io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			reader = gz
+		}
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(reader).Decode(&body))
+		bodies = append(bodies, body)
+		fmt.Fprint(w, `{"success":true,"id":"job"}`)
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", server.URL, WithRequestCompression(1024))
+	require.NoError(t, err)
+
+	urls := make([]string, 100)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/page/%d", i)
+	}
+	_, err = app.AsyncBatchScrapeURLs(urls, nil, nil)
+	require.NoError(t, err)
+	_, err = app.AsyncBatchScrapeURLs(urls[:1], nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"gzip", ""}, encodings)
+	require.Len(t, bodies, 2)
+	assert.Len(t, bodies[0]["urls"], 100)
+	assert.Len(t, bodies[1]["urls"], 1)
+}
+
+func TestWithHTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"success":true,"data":{"markdown":%q}}`, r.Proto)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	for _, test := range []struct {
+		enabled bool
+		proto   string
+	}{
+		{enabled: true, proto: "HTTP/2.0"},
+		{enabled: false, proto: "HTTP/1.1"},
+	} {
+		app, err := NewFirecrawlApp("fc-test", server.URL,
+			WithTransport(server.Client().Transport),
+			WithHTTP2(test.enabled),
+			WithKeepAlive(15*time.Second),
+		)
+		require.NoError(t, err)
+
+		response, err := app.ScrapeURL("https://example.com", nil)
+		require.NoError(t, err)
+		assert.Equal(t, test.proto, response.Markdown)
+	}
+}
@@ -0,0 +1,348 @@
+package firecrawl
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MergeScrapeParams merges two sets of scrape parameters into a new ScrapeParams.
+// Every field set in override (a non-nil pointer or slice) replaces the corresponding
+// field of base; slices are replaced, not appended. Neither argument is modified, but the
+// result shares the pointers, slices and maps of its inputs. Wait and WaitFor are treated as a
+// single option: setting either in override clears both in base.
+//
+// Parameters:
+//   - base: The default scrape parameters (can be nil).
+//   - override: The scrape parameters that take precedence (can be nil).
+//
+// Returns:
+//   - *ScrapeParams: The merged scrape parameters.
+func MergeScrapeParams(base, override *ScrapeParams) *ScrapeParams {
+	merged := &ScrapeParams{}
+	if base != nil {
+		*merged = *base
+	}
+	if override == nil {
+		return merged
+	}
+	if override.Wait != nil || override.WaitFor != nil {
+		merged.Wait = nil
+		merged.WaitFor = nil
+	}
+
+	if override.Formats != nil {
+		merged.Formats = override.Formats
+	}
+	if override.Headers != nil {
+		merged.Headers = override.Headers
+	}
+	if override.IncludeTags != nil {
+		merged.IncludeTags = override.IncludeTags
+	}
+	if override.ExcludeTags != nil {
+		merged.ExcludeTags = override.ExcludeTags
+	}
+	if override.OnlyMainContent != nil {
+		merged.OnlyMainContent = override.OnlyMainContent
+	}
+	if override.WaitFor != nil {
+		merged.WaitFor = override.WaitFor
+	}
+	if override.ParsePDF != nil {
+		merged.ParsePDF = override.ParsePDF
+	}
+	if override.Timeout != nil {
+		merged.Timeout = override.Timeout
+	}
+	if override.Actions != nil {
+		merged.Actions = override.Actions
+	}
+	if override.Location != nil {
+		merged.Location = override.Location
+	}
+	if override.FastMode != nil {
+		merged.FastMode = override.FastMode
+	}
+	if override.Wait != nil {
+		merged.Wait = override.Wait
+	}
+	return merged
+}
+
+// Credit costs used by EstimateCrawlCost.
+const (
+	creditsPerPage          = 1
+	creditsPerJSONExtension = 4
+)
+
+// EstimateCrawlCost returns a rough, local estimate of the credits a crawl can consume, without calling
+// the API. It assumes every page up to params.Limit is scraped at 1 credit, plus 4 credits per page when
+// the "json" or "extract" format is requested. Actual usage depends on how many pages the crawl finds
+// and on the plan's pricing, so treat the result as an upper bound for budgeting.
+//
+// Parameters:
+//   - params: The parameters of the crawl to estimate. Limit must be set.
+//
+// Returns:
+//   - int: The estimated maximum number of credits.
+//   - error: An error if params or params.Limit is not set.
+func EstimateCrawlCost(params *CrawlParams) (int, error) {
+	if params == nil || params.Limit == nil {
+		return 0, fmt.Errorf("crawl limit is required to estimate its cost")
+	}
+	if *params.Limit < 0 {
+		return 0, fmt.Errorf("invalid crawl limit: %d", *params.Limit)
+	}
+
+	perPage := creditsPerPage
+	for _, format := range params.ScrapeOptions.Formats {
+		if format == "json" || format == "extract" {
+			perPage += creditsPerJSONExtension
+			break
+		}
+	}
+
+	return *params.Limit * perPage, nil
+}
+
+// FilterLinks returns the mapped links whose path matches the given patterns, the way
+// CrawlParams.IncludePaths and ExcludePaths filter a crawl. The map API cannot filter links by path
+// itself, so the filtering happens on the client after the whole site has been mapped.
+//
+// Parameters:
+//   - includePaths: Regular expressions of which a link's path must match one (all links if empty).
+//   - excludePaths: Regular expressions of which a link's path must match none.
+//
+// Returns:
+//   - []string: The matching links, in their original order.
+//   - error: An error if a pattern is not a valid regular expression.
+func (r *MapResponse) FilterLinks(includePaths, excludePaths []string) ([]string, error) {
+	compile := func(patterns []string) ([]*regexp.Regexp, error) {
+		compiled := make([]*regexp.Regexp, len(patterns))
+		for i, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path pattern %q: %v", pattern, err)
+			}
+			compiled[i] = re
+		}
+		return compiled, nil
+	}
+	matchesAny := func(patterns []*regexp.Regexp, path string) bool {
+		for _, re := range patterns {
+			if re.MatchString(path) {
+				return true
+			}
+		}
+		return false
+	}
+
+	includes, err := compile(includePaths)
+	if err != nil {
+		return nil, err
+	}
+	excludes, err := compile(excludePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []string
+	for _, link := range r.Links {
+		path := link
+		if u, err := url.Parse(link); err == nil {
+			path = u.Path
+		}
+		if len(includes) > 0 && !matchesAny(includes, path) {
+			continue
+		}
+		if matchesAny(excludes, path) {
+			continue
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// SetBasicAuth sets an HTTP basic auth Authorization header in the scrape's Headers, which are sent
+// to the scraped page. They are separate from the headers of the Firecrawl API request itself, so
+// the page's credentials never interfere with the API key.
+//
+// Parameters:
+//   - username: The username for the scraped page.
+//   - password: The password for the scraped page.
+func (params *ScrapeParams) SetBasicAuth(username, password string) {
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	params.setHeader("Authorization", "Basic "+credentials)
+}
+
+// SetReferer sets the Referer header sent to the scraped page, for sites that gate content on it.
+// It takes precedence over any Referer already in Headers, whatever its capitalization, which it
+// replaces.
+//
+// Parameters:
+//   - referer: The URL sent as the Referer of the scraped page.
+func (params *ScrapeParams) SetReferer(referer string) {
+	params.setHeader("Referer", referer)
+}
+
+// setHeader sets a header in a copy of the scrape's Headers, replacing any header with the same
+// name in a different capitalization. The caller's map is not modified.
+//
+// Parameters:
+//   - name: The name of the header.
+//   - value: The value of the header.
+func (params *ScrapeParams) setHeader(name, value string) {
+	headers := map[string]string{}
+	if params.Headers != nil {
+		for key, existing := range *params.Headers {
+			if !strings.EqualFold(key, name) {
+				headers[key] = existing
+			}
+		}
+	}
+	headers[name] = value
+	params.Headers = &headers
+}
+
+// ScrapeParamsBuilder builds ScrapeParams without taking the address of every option.
+// Each method sets one option and returns the builder, so calls can be chained:
+//
+//	params := firecrawl.NewScrapeParams().
+//		WithFormats("markdown", "html").
+//		WithWaitFor(1000).
+//		OnlyMainContent().
+//		Build()
+type ScrapeParamsBuilder struct {
+	params ScrapeParams
+}
+
+// NewScrapeParams returns a builder for ScrapeParams with no option set.
+//
+// Returns:
+//   - *ScrapeParamsBuilder: A new builder.
+func NewScrapeParams() *ScrapeParamsBuilder {
+	return &ScrapeParamsBuilder{}
+}
+
+// WithFormats sets the formats to return, e.g. "markdown" or "html".
+func (b *ScrapeParamsBuilder) WithFormats(formats ...string) *ScrapeParamsBuilder {
+	b.params.Formats = append([]string(nil), formats...)
+	return b
+}
+
+// WithHeaders sets the headers sent to the scraped page.
+func (b *ScrapeParamsBuilder) WithHeaders(headers map[string]string) *ScrapeParamsBuilder {
+	copied := make(map[string]string, len(headers))
+	for name, value := range headers {
+		copied[name] = value
+	}
+	b.params.Headers = &copied
+	return b
+}
+
+// WithReferer sets the Referer header sent to the scraped page. See ScrapeParams.SetReferer.
+func (b *ScrapeParamsBuilder) WithReferer(referer string) *ScrapeParamsBuilder {
+	b.params.SetReferer(referer)
+	return b
+}
+
+// WithIncludeTags sets the tags or CSS selectors to include in the output.
+func (b *ScrapeParamsBuilder) WithIncludeTags(tags ...string) *ScrapeParamsBuilder {
+	b.params.IncludeTags = append([]string(nil), tags...)
+	return b
+}
+
+// WithExcludeTags sets the tags or CSS selectors to exclude from the output.
+func (b *ScrapeParamsBuilder) WithExcludeTags(tags ...string) *ScrapeParamsBuilder {
+	b.params.ExcludeTags = append([]string(nil), tags...)
+	return b
+}
+
+// OnlyMainContent restricts the output to the main content of the page.
+func (b *ScrapeParamsBuilder) OnlyMainContent() *ScrapeParamsBuilder {
+	onlyMainContent := true
+	b.params.OnlyMainContent = &onlyMainContent
+	return b
+}
+
+// FastMode scrapes the page with the fastest engine, trading accuracy for speed.
+func (b *ScrapeParamsBuilder) FastMode() *ScrapeParamsBuilder {
+	fastMode := true
+	b.params.FastMode = &fastMode
+	return b
+}
+
+// WithWaitFor sets how long to wait (in milliseconds) for the page to load before scraping it.
+func (b *ScrapeParamsBuilder) WithWaitFor(milliseconds int) *ScrapeParamsBuilder {
+	b.params.WaitFor = &milliseconds
+	return b
+}
+
+// WithWait sets how the page is waited for before it is scraped. See ScrapeParams.Wait.
+func (b *ScrapeParamsBuilder) WithWait(strategy *WaitStrategy) *ScrapeParamsBuilder {
+	b.params.Wait = strategy
+	return b
+}
+
+// WithParsePDF sets whether PDF files are parsed into the output formats.
+func (b *ScrapeParamsBuilder) WithParsePDF(parsePDF bool) *ScrapeParamsBuilder {
+	b.params.ParsePDF = &parsePDF
+	return b
+}
+
+// WithTimeout sets the timeout (in milliseconds) of the scrape.
+func (b *ScrapeParamsBuilder) WithTimeout(milliseconds int) *ScrapeParamsBuilder {
+	b.params.Timeout = &milliseconds
+	return b
+}
+
+// WithActions sets the browser actions performed on the page before it is scraped.
+func (b *ScrapeParamsBuilder) WithActions(actions ...Action) *ScrapeParamsBuilder {
+	b.params.Actions = append([]Action(nil), actions...)
+	return b
+}
+
+// WithLocation sets the country, and optionally the languages, the page is scraped from.
+func (b *ScrapeParamsBuilder) WithLocation(country string, languages ...string) *ScrapeParamsBuilder {
+	location := &LocationParams{Country: &country}
+	if len(languages) > 0 {
+		location.Languages = append([]string(nil), languages...)
+	}
+	b.params.Location = location
+	return b
+}
+
+// Build returns the built scrape parameters. Later calls on the builder do not modify them.
+//
+// Returns:
+//   - *ScrapeParams: The scrape parameters.
+func (b *ScrapeParamsBuilder) Build() *ScrapeParams {
+	params := b.params
+	return &params
+}
+
+// queryString encodes the status parameters as a URL query string.
+//
+// Returns:
+//   - string: The query string including its leading "?", or an empty string if no parameter is set.
+func (params *StatusParams) queryString() string {
+	if params == nil {
+		return ""
+	}
+
+	query := url.Values{}
+	if params.Skip != nil {
+		query.Set("skip", strconv.Itoa(*params.Skip))
+	}
+	if params.Limit != nil {
+		query.Set("limit", strconv.Itoa(*params.Limit))
+	}
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
@@ -0,0 +1,190 @@
+package firecrawl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeScrapeParams(t *testing.T) {
+	base := &ScrapeParams{
+		Formats:         []string{"markdown", "html"},
+		IncludeTags:     []string{"article"},
+		OnlyMainContent: ptr(true),
+		Timeout:         ptr(30000),
+	}
+	override := &ScrapeParams{
+		Formats:         []string{"links"},
+		OnlyMainContent: ptr(false),
+		WaitFor:         ptr(1000),
+	}
+
+	merged := MergeScrapeParams(base, override)
+	require.NotNil(t, merged)
+
+	assert.Equal(t, []string{"links"}, merged.Formats)
+	assert.Equal(t, []string{"article"}, merged.IncludeTags)
+	assert.False(t, *merged.OnlyMainContent)
+	assert.Equal(t, 30000, *merged.Timeout)
+	assert.Equal(t, 1000, *merged.WaitFor)
+
+	assert.Equal(t, []string{"markdown", "html"}, base.Formats)
+	assert.True(t, *base.OnlyMainContent)
+	assert.Nil(t, base.WaitFor)
+}
+
+func TestMergeScrapeParamsWait(t *testing.T) {
+	base := &ScrapeParams{Wait: WaitForSelector("#prices"), Timeout: ptr(30000)}
+
+	merged := MergeScrapeParams(base, &ScrapeParams{WaitFor: ptr(1000)})
+	assert.Nil(t, merged.Wait)
+	assert.Equal(t, 1000, *merged.WaitFor)
+	assert.Equal(t, 30000, *merged.Timeout)
+
+	merged = MergeScrapeParams(&ScrapeParams{WaitFor: ptr(5000)}, &ScrapeParams{Wait: WaitForDuration(1000)})
+	assert.Nil(t, merged.WaitFor)
+	assert.Equal(t, WaitForDuration(1000), merged.Wait)
+
+	merged = MergeScrapeParams(base, &ScrapeParams{Formats: []string{"markdown"}})
+	assert.Same(t, base.Wait, merged.Wait)
+}
+
+func TestMergeScrapeParamsEveryField(t *testing.T) {
+	override := &ScrapeParams{
+		Formats:         []string{"markdown"},
+		Headers:         &map[string]string{"x-key": "test"},
+		IncludeTags:     []string{"main"},
+		ExcludeTags:     []string{"nav"},
+		OnlyMainContent: ptr(true),
+		WaitFor:         ptr(1000),
+		ParsePDF:        ptr(false),
+		Timeout:         ptr(30000),
+		Actions:         []Action{{Type: ActionTypeScrape}},
+		Location:        &LocationParams{Country: ptr("DE")},
+		FastMode:        ptr(true),
+		Wait:            WaitForSelector("#content"),
+	}
+
+	// Every field must be set above, so that fields added later are merged too.
+	value := reflect.ValueOf(override).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		require.False(t, value.Field(i).IsZero(), value.Type().Field(i).Name)
+	}
+
+	assert.Equal(t, override, MergeScrapeParams(&ScrapeParams{}, override))
+}
+
+func TestMergeScrapeParamsNil(t *testing.T) {
+	assert.Equal(t, &ScrapeParams{}, MergeScrapeParams(nil, nil))
+
+	override := &ScrapeParams{Formats: []string{"markdown"}}
+	assert.Equal(t, override, MergeScrapeParams(nil, override))
+
+	base := &ScrapeParams{Timeout: ptr(1000)}
+	merged := MergeScrapeParams(base, nil)
+	assert.Equal(t, base, merged)
+	assert.NotSame(t, base, merged)
+}
+
+func TestEstimateCrawlCost(t *testing.T) {
+	cost, err := EstimateCrawlCost(&CrawlParams{Limit: ptr(100)})
+	require.NoError(t, err)
+	assert.Equal(t, 100, cost)
+
+	cost, err = EstimateCrawlCost(&CrawlParams{
+		Limit:         ptr(100),
+		ScrapeOptions: ScrapeParams{Formats: []string{"markdown", "json"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 500, cost)
+
+	_, err = EstimateCrawlCost(&CrawlParams{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "crawl limit is required")
+}
+
+func TestScrapeParamsSetBasicAuth(t *testing.T) {
+	original := map[string]string{"x-key": "test"}
+	params := &ScrapeParams{Headers: &original}
+
+	params.SetBasicAuth("alice", "s3cret")
+
+	require.NotNil(t, params.Headers)
+	assert.Equal(t, "Basic YWxpY2U6czNjcmV0", (*params.Headers)["Authorization"])
+	assert.Equal(t, "test", (*params.Headers)["x-key"])
+	assert.NotContains(t, original, "Authorization")
+}
+
+func TestScrapeParamsSetReferer(t *testing.T) {
+	original := map[string]string{"referer": "https://old.example.com", "x-key": "test"}
+	params := &ScrapeParams{Headers: &original}
+
+	params.SetReferer("https://example.com/search")
+
+	assert.Equal(t, map[string]string{"Referer": "https://example.com/search", "x-key": "test"}, *params.Headers)
+	assert.Equal(t, "https://old.example.com", original["referer"])
+}
+
+func TestStatusParamsQueryString(t *testing.T) {
+	var params *StatusParams
+	assert.Equal(t, "", params.queryString())
+	assert.Equal(t, "", (&StatusParams{}).queryString())
+	assert.Equal(t, "?skip=10", (&StatusParams{Skip: ptr(10)}).queryString())
+	assert.Equal(t, "?limit=5&skip=10", (&StatusParams{Skip: ptr(10), Limit: ptr(5)}).queryString())
+}
+
+func TestScrapeParamsBuilder(t *testing.T) {
+	builder := NewScrapeParams().
+		WithFormats("markdown", "html").
+		WithHeaders(map[string]string{"Referer": "https://example.com"}).
+		WithIncludeTags("article").
+		WithExcludeTags("nav", "#footer").
+		OnlyMainContent().
+		WithWaitFor(1000).
+		WithParsePDF(false).
+		WithTimeout(30000).
+		WithActions(Action{Type: ActionTypeWait, Milliseconds: ptr(500)}).
+		WithLocation("DE", "de-DE").
+		FastMode().
+		WithWait(WaitForSelector("#main"))
+
+	params := builder.Build()
+
+	assert.Equal(t, &ScrapeParams{
+		Formats:         []string{"markdown", "html"},
+		Headers:         &map[string]string{"Referer": "https://example.com"},
+		IncludeTags:     []string{"article"},
+		ExcludeTags:     []string{"nav", "#footer"},
+		OnlyMainContent: ptr(true),
+		WaitFor:         ptr(1000),
+		ParsePDF:        ptr(false),
+		Timeout:         ptr(30000),
+		Actions:         []Action{{Type: ActionTypeWait, Milliseconds: ptr(500)}},
+		Location:        &LocationParams{Country: ptr("DE"), Languages: []string{"de-DE"}},
+		FastMode:        ptr(true),
+		Wait:            WaitForSelector("#main"),
+	}, params)
+
+	builder.WithWaitFor(2000).WithFormats("links")
+	assert.Equal(t, 1000, *params.WaitFor)
+	assert.Equal(t, []string{"markdown", "html"}, params.Formats)
+}
+
+func TestMapResponseFilterLinks(t *testing.T) {
+	response := &MapResponse{Links: []string{
+		"https://example.com/",
+		"https://example.com/docs",
+		"https://example.com/docs/getting-started",
+		"https://example.com/docs/internal/notes",
+		"https://example.com/blog/docs-launch",
+	}}
+
+	links, err := response.FilterLinks([]string{"^/docs"}, []string{"^/docs/internal"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/docs", "https://example.com/docs/getting-started"}, links)
+
+	_, err = response.FilterLinks([]string{"("}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package firecrawl
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxSlugLength is the maximum length of a file name slug, excluding its hash suffix.
+const maxSlugLength = 100
+
+var slugSeparatorPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// SaveToDir writes every document of the crawl to the directory at path, creating it if needed.
+// Each document is saved as "<slug>.md" and "<slug>.html" for its markdown and HTML, and
+// "<slug>.<ext>" for a base64 screenshot, where the slug is derived from the document's source URL.
+// Screenshots returned as URLs are not downloaded; their URL is written to "<slug>.screenshot.txt".
+// Formats that are empty and nil documents are skipped.
+//
+// Parameters:
+//   - path: The directory to write the documents to.
+//
+// Returns:
+//   - error: An error if the response is nil, or the directory or a file cannot be written.
+func (r *CrawlStatusResponse) SaveToDir(path string) error {
+	if r == nil {
+		return fmt.Errorf("cannot save a nil crawl response")
+	}
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %v", path, err)
+	}
+
+	used := make(map[string]bool)
+	for i, doc := range r.Data {
+		if doc == nil {
+			continue
+		}
+
+		base := documentSlug(doc, i)
+		slug := base
+		for n := 2; used[slug]; n++ {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[slug] = true
+
+		files := make(map[string][]byte)
+		if doc.Markdown != "" {
+			files[slug+".md"] = []byte(doc.Markdown)
+		}
+		if doc.HTML != "" {
+			files[slug+".html"] = []byte(doc.HTML)
+		}
+		if doc.Screenshot != "" {
+			name, data, err := screenshotFile(slug, doc.Screenshot)
+			if err != nil {
+				return err
+			}
+			files[name] = data
+		}
+
+		for name, data := range files {
+			if err := os.WriteFile(filepath.Join(path, name), data, 0o644); err != nil {
+				return fmt.Errorf("failed to write %s: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// documentSlug derives a file name from the document's source URL. Slugs of long URLs are
+// truncated and suffixed with a hash of the URL so they stay unique.
+//
+// Parameters:
+//   - doc: The document to name.
+//   - index: The position of the document, used when it has no URL.
+//
+// Returns:
+//   - string: A file name made of lowercase letters, digits and dashes.
+func documentSlug(doc *FirecrawlDocument, index int) string {
+	var rawURL string
+	if doc.Metadata != nil && doc.Metadata.SourceURL != nil {
+		rawURL = *doc.Metadata.SourceURL
+	} else if doc.Metadata != nil && doc.Metadata.ResolvedURL != nil {
+		rawURL = *doc.Metadata.ResolvedURL
+	}
+	if rawURL == "" {
+		return fmt.Sprintf("document-%d", index)
+	}
+
+	name := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		name = u.Host + u.Path
+		if u.RawQuery != "" {
+			name += "-" + u.RawQuery
+		}
+	}
+
+	slug := strings.Trim(slugSeparatorPattern.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if slug == "" {
+		slug = fmt.Sprintf("document-%d", index)
+	}
+	if len(slug) > maxSlugLength {
+		sum := sha256.Sum256([]byte(rawURL))
+		slug = strings.TrimRight(slug[:maxSlugLength], "-") + "-" + hex.EncodeToString(sum[:4])
+	}
+	return slug
+}
+
+// screenshotFile returns the file name and content used to save a screenshot.
+//
+// Parameters:
+//   - slug: The slug of the document.
+//   - screenshot: The screenshot, either a base64 data URL or a URL to the image.
+//
+// Returns:
+//   - string: The file name.
+//   - []byte: The file content.
+//   - error: An error if a data URL cannot be decoded.
+func screenshotFile(slug, screenshot string) (string, []byte, error) {
+	if !strings.HasPrefix(screenshot, "data:") {
+		return slug + ".screenshot.txt", []byte(screenshot), nil
+	}
+
+	header, payload, ok := strings.Cut(strings.TrimPrefix(screenshot, "data:"), ",")
+	if !ok || !strings.HasSuffix(header, ";base64") {
+		return "", nil, fmt.Errorf("invalid screenshot data URL for %s", slug)
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode screenshot for %s: %v", slug, err)
+	}
+
+	switch strings.TrimSuffix(header, ";base64") {
+	case "image/jpeg":
+		return slug + ".jpg", data, nil
+	case "image/webp":
+		return slug + ".webp", data, nil
+	default:
+		return slug + ".png", data, nil
+	}
+}
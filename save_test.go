@@ -0,0 +1,73 @@
+package firecrawl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrawlStatusResponseSaveToDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "archive")
+	response := &CrawlStatusResponse{
+		Status: "completed",
+		Data: []*FirecrawlDocument{
+			{
+				Markdown:   "# Home",
+				HTML:       "<h1>Home</h1>",
+				Screenshot: "data:image/png;base64,aGVsbG8=",
+				Metadata:   &FirecrawlDocumentMetadata{SourceURL: ptr("https://Example.com/")},
+			},
+			{
+				Markdown:   "# Docs",
+				Screenshot: "https://cdn.example.com/shot.png",
+				Metadata:   &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/docs/Getting Started?page=2")},
+			},
+			{
+				Markdown: "# Docs again",
+				Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr("https://example.com/docs/getting-started-page-2")},
+			},
+			{Markdown: "# No URL"},
+		},
+	}
+
+	require.NoError(t, response.SaveToDir(dir))
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "# Home", read("example-com.md"))
+	assert.Equal(t, "<h1>Home</h1>", read("example-com.html"))
+	assert.Equal(t, "hello", read("example-com.png"))
+	assert.Equal(t, "# Docs", read("example-com-docs-getting-started-page-2.md"))
+	assert.Equal(t, "https://cdn.example.com/shot.png", read("example-com-docs-getting-started-page-2.screenshot.txt"))
+	assert.Equal(t, "# Docs again", read("example-com-docs-getting-started-page-2-2.md"))
+	assert.Equal(t, "# No URL", read("document-3.md"))
+}
+
+func TestCrawlStatusResponseSaveToDirNil(t *testing.T) {
+	dir := t.TempDir()
+
+	var response *CrawlStatusResponse
+	assert.ErrorContains(t, response.SaveToDir(dir), "nil crawl response")
+
+	response = &CrawlStatusResponse{Data: []*FirecrawlDocument{nil, {Markdown: "# Page"}}}
+	require.NoError(t, response.SaveToDir(dir))
+	data, err := os.ReadFile(filepath.Join(dir, "document-1.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Page", string(data))
+}
+
+func TestDocumentSlugLongURL(t *testing.T) {
+	long := "https://example.com/" + strings.Repeat("segment/", 30)
+	slug := documentSlug(&FirecrawlDocument{Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr(long)}}, 0)
+	other := documentSlug(&FirecrawlDocument{Metadata: &FirecrawlDocumentMetadata{SourceURL: ptr(long + "x")}}, 0)
+
+	assert.LessOrEqual(t, len(slug), maxSlugLength+9)
+	assert.NotEqual(t, slug, other)
+}
@@ -0,0 +1,125 @@
+package firecrawl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// jsonSchemaTypes lists the types a JSON Schema "type" keyword can name.
+var jsonSchemaTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true,
+	"integer": true, "boolean": true, "null": true,
+}
+
+// LoadSchema loads and checks a JSON Schema file, so the same schema file can be shared with
+// services written in other languages. The schema is checked structurally: it must be a JSON
+// object, "type" must name valid JSON Schema types, "properties" must map names to schemas,
+// "required" must list strings and "items" must be a schema. Other keywords are kept unchecked.
+// ScrapeParams does not model the "json" format's jsonOptions yet, so the schema cannot be sent
+// for LLM extraction through this client.
+//
+// Parameters:
+//   - path: The path of the JSON Schema file.
+//
+// Returns:
+//   - map[string]any: The decoded schema.
+//   - error: An error if the file cannot be read or is not a valid JSON Schema.
+func LoadSchema(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %v", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse schema %s: %v", path, err)
+	}
+	if schema == nil {
+		return nil, fmt.Errorf("invalid schema %s: not a JSON object", path)
+	}
+	if err := validateSchema(schema, "#"); err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %v", path, err)
+	}
+	return schema, nil
+}
+
+// validateSchema checks the structure of a JSON Schema and of its nested schemas.
+//
+// Parameters:
+//   - schema: The schema to check.
+//   - pointer: The JSON pointer of the schema, used in error messages.
+//
+// Returns:
+//   - error: An error describing the first invalid keyword found.
+func validateSchema(schema map[string]any, pointer string) error {
+	if schemaType, ok := schema["type"]; ok {
+		var types []any
+		switch t := schemaType.(type) {
+		case string:
+			types = []any{t}
+		case []any:
+			types = t
+		default:
+			return fmt.Errorf("%s/type: must be a string or an array of strings", pointer)
+		}
+		for _, t := range types {
+			name, ok := t.(string)
+			if !ok || !jsonSchemaTypes[name] {
+				return fmt.Errorf("%s/type: unknown type %v", pointer, t)
+			}
+		}
+	}
+
+	if properties, ok := schema["properties"]; ok {
+		propertyMap, ok := properties.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s/properties: must be an object", pointer)
+		}
+		for name, property := range propertyMap {
+			propertyPointer := pointer + "/properties/" + escapeJSONPointer(name)
+			propertySchema, ok := property.(map[string]any)
+			if !ok {
+				if _, ok := property.(bool); ok {
+					continue
+				}
+				return fmt.Errorf("%s: must be a schema", propertyPointer)
+			}
+			if err := validateSchema(propertySchema, propertyPointer); err != nil {
+				return err
+			}
+		}
+	}
+
+	if required, ok := schema["required"]; ok {
+		names, ok := required.([]any)
+		if !ok {
+			return fmt.Errorf("%s/required: must be an array of strings", pointer)
+		}
+		for _, name := range names {
+			if _, ok := name.(string); !ok {
+				return fmt.Errorf("%s/required: must be an array of strings", pointer)
+			}
+		}
+	}
+
+	if items, ok := schema["items"]; ok {
+		switch itemSchema := items.(type) {
+		case map[string]any:
+			if err := validateSchema(itemSchema, pointer+"/items"); err != nil {
+				return err
+			}
+		case bool:
+		default:
+			return fmt.Errorf("%s/items: must be a schema", pointer)
+		}
+	}
+
+	return nil
+}
+
+// escapeJSONPointer escapes a property name for use in a JSON pointer.
+func escapeJSONPointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,56 @@
+package firecrawl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSchema(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadSchema(t *testing.T) {
+	path := writeSchema(t, `{
+		"type": "object",
+		"properties": {
+			"title": {"type": "string"},
+			"points": {"type": ["number", "null"]},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": ["title"]
+	}`)
+
+	schema, err := LoadSchema(path)
+	require.NoError(t, err)
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []any{"title"}, schema["required"])
+}
+
+func TestLoadSchemaInvalid(t *testing.T) {
+	tests := map[string]string{
+		"not JSON":          `{"type":`,
+		"not an object":     `["object"]`,
+		"unknown type":      `{"type": "text"}`,
+		"bad properties":    `{"type": "object", "properties": ["title"]}`,
+		"bad property":      `{"type": "object", "properties": {"title": "string"}}`,
+		"bad nested type":   `{"type": "object", "properties": {"title": {"type": "str"}}}`,
+		"bad required":      `{"type": "object", "required": "title"}`,
+		"bad required item": `{"type": "object", "required": [1]}`,
+		"bad items":         `{"type": "array", "items": "string"}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadSchema(writeSchema(t, content))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := LoadSchema(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,193 @@
+package firecrawl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SitemapEntry represents a page listed in a sitemap.
+type SitemapEntry struct {
+	URL          string     `json:"url"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+}
+
+// sitemapDocument represents a sitemap or a sitemap index as decoded from XML.
+type sitemapDocument struct {
+	URLs []struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// sitemapTimeLayouts are the W3C datetime layouts allowed for a sitemap lastmod.
+var sitemapTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04Z07:00", "2006-01-02", "2006-01", "2006"}
+
+// maxRobotsTxtSize is the maximum size of a robots.txt file read by DiscoverSitemaps.
+const maxRobotsTxtSize = 512 << 10
+
+// DiscoverSitemaps returns the URLs of a site's sitemaps, as declared by the Sitemap lines of its
+// robots.txt. If robots.txt declares none, the conventional /sitemap.xml is returned when it exists.
+// The crawl and map endpoints use a site's sitemaps without reporting them, so they are looked up
+// directly on the site, without the Firecrawl API key. Pass the results to FetchSitemap to list
+// their pages.
+//
+// Parameters:
+//   - ctx: The context used to cancel the requests.
+//   - siteURL: Any URL of the site, e.g. "https://example.com".
+//
+// Returns:
+//   - []string: The sitemap URLs, in the order declared, or nil if the site has none.
+//   - error: An error if the URL is invalid or the site cannot be reached.
+func (app *FirecrawlApp) DiscoverSitemaps(ctx context.Context, siteURL string) ([]string, error) {
+	site, err := url.Parse(siteURL)
+	if err != nil || site.Scheme == "" || site.Host == "" {
+		return nil, fmt.Errorf("invalid site URL: %s", siteURL)
+	}
+	root := &url.URL{Scheme: site.Scheme, Host: site.Host}
+
+	var sitemaps []string
+	robots, _, err := app.download(ctx, root.JoinPath("robots.txt").String(), "robots.txt", maxRobotsTxtSize, nil)
+	if err == nil {
+		seen := make(map[string]bool)
+		for _, line := range strings.Split(string(robots), "\n") {
+			key, value, ok := strings.Cut(line, ":")
+			value = strings.TrimSpace(value)
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "sitemap") || value == "" || seen[value] {
+				continue
+			}
+			seen[value] = true
+			sitemaps = append(sitemaps, value)
+		}
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if len(sitemaps) > 0 {
+		return sitemaps, nil
+	}
+
+	fallback := root.JoinPath("sitemap.xml").String()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, fallback, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil
+	}
+	return []string{fallback}, nil
+}
+
+// FetchSitemap downloads a sitemap and returns the pages it lists with their last modification
+// date, to re-scrape only the pages changed since a previous run. MapURL returns links without
+// dates, so the sitemap is fetched directly from the site, without the Firecrawl API key.
+// A sitemap index is followed one level deep, gzip-compressed sitemaps are decompressed, and
+// lastmod values that are not valid W3C datetimes are ignored.
+//
+// Parameters:
+//   - ctx: The context used to cancel the downloads.
+//   - sitemapURL: The URL of the sitemap or sitemap index, e.g. "https://example.com/sitemap.xml".
+//   - maxBytes: The maximum size of each sitemap in bytes. Zero or a negative value disables the limit.
+//
+// Returns:
+//   - []SitemapEntry: The pages listed in the sitemap, in order.
+//   - error: An error if a sitemap cannot be downloaded or decoded.
+func (app *FirecrawlApp) FetchSitemap(ctx context.Context, sitemapURL string, maxBytes int64) ([]SitemapEntry, error) {
+	sitemap, err := app.fetchSitemapDocument(ctx, sitemapURL, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := sitemapEntries(sitemap)
+	for _, child := range sitemap.Sitemaps {
+		childSitemap, err := app.fetchSitemapDocument(ctx, strings.TrimSpace(child.Loc), maxBytes)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, sitemapEntries(childSitemap)...)
+	}
+	return entries, nil
+}
+
+// fetchSitemapDocument downloads and decodes a sitemap or sitemap index.
+//
+// Parameters:
+//   - ctx: The context used to cancel the download.
+//   - sitemapURL: The URL of the sitemap.
+//   - maxBytes: The maximum size of the sitemap in bytes. Zero or a negative value disables the limit.
+//
+// Returns:
+//   - *sitemapDocument: The decoded sitemap.
+//   - error: An error if the sitemap cannot be downloaded or decoded.
+func (app *FirecrawlApp) fetchSitemapDocument(ctx context.Context, sitemapURL string, maxBytes int64) (*sitemapDocument, error) {
+	data, _, err := app.download(ctx, sitemapURL, "sitemap", maxBytes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress sitemap %s: %v", sitemapURL, err)
+		}
+		defer gz.Close()
+
+		var body io.Reader = gz
+		if maxBytes > 0 {
+			body = io.LimitReader(gz, maxBytes+1)
+		}
+		data, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress sitemap %s: %v", sitemapURL, err)
+		}
+		if maxBytes > 0 && int64(len(data)) > maxBytes {
+			return nil, fmt.Errorf("sitemap %s exceeds maximum size of %d bytes", sitemapURL, maxBytes)
+		}
+	}
+
+	var sitemap sitemapDocument
+	if err := xml.Unmarshal(data, &sitemap); err != nil {
+		return nil, fmt.Errorf("failed to decode sitemap %s: %v", sitemapURL, err)
+	}
+	return &sitemap, nil
+}
+
+// sitemapEntries returns the pages listed in a decoded sitemap.
+//
+// Parameters:
+//   - sitemap: The decoded sitemap.
+//
+// Returns:
+//   - []SitemapEntry: The pages, in order.
+func sitemapEntries(sitemap *sitemapDocument) []SitemapEntry {
+	var entries []SitemapEntry
+	for _, u := range sitemap.URLs {
+		entry := SitemapEntry{URL: strings.TrimSpace(u.Loc)}
+		if entry.URL == "" {
+			continue
+		}
+		lastMod := strings.TrimSpace(u.LastMod)
+		for _, layout := range sitemapTimeLayouts {
+			if t, err := time.Parse(layout, lastMod); err == nil {
+				entry.LastModified = &t
+				break
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
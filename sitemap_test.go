@@ -0,0 +1,100 @@
+package firecrawl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchSitemap(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>%[1]s/pages.xml</loc></sitemap>
+  <sitemap><loc>%[1]s/blog.xml.gz</loc></sitemap>
+</sitemapindex>`, server.URL)
+		case "/pages.xml":
+			fmt.Fprint(w, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/</loc><lastmod>2024-05-01T10:30:00+02:00</lastmod></url>
+  <url><loc> https://example.com/about </loc></url>
+  <url><loc>https://example.com/team</loc><lastmod>last week</lastmod></url>
+</urlset>`)
+		case "/blog.xml.gz":
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			fmt.Fprint(gz, `<urlset><url><loc>https://example.com/blog/1</loc><lastmod>2024-06-15</lastmod></url></urlset>`)
+			gz.Close()
+			w.Write(buf.Bytes())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev")
+	require.NoError(t, err)
+
+	entries, err := app.FetchSitemap(context.Background(), server.URL+"/sitemap.xml", 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, "https://example.com/", entries[0].URL)
+	require.NotNil(t, entries[0].LastModified)
+	assert.True(t, entries[0].LastModified.Equal(time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)))
+	assert.Equal(t, "https://example.com/about", entries[1].URL)
+	assert.Nil(t, entries[1].LastModified)
+	assert.Nil(t, entries[2].LastModified)
+	assert.Equal(t, "https://example.com/blog/1", entries[3].URL)
+	assert.Equal(t, time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC), *entries[3].LastModified)
+
+	_, err = app.FetchSitemap(context.Background(), server.URL+"/missing.xml", 0)
+	assert.ErrorContains(t, err, "status code 404")
+}
+
+func TestDiscoverSitemaps(t *testing.T) {
+	robots := "User-agent: *\r\nDisallow: /admin\r\nSitemap: https://example.com/sitemap_index.xml\r\nsitemap:https://example.com/news.xml\r\nSITEMAP: https://example.com/news.xml\r\n"
+	hasSitemap := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/robots.txt" && robots != "":
+			fmt.Fprint(w, robots)
+		case r.URL.Path == "/sitemap.xml" && hasSitemap:
+			assert.Equal(t, http.MethodHead, r.Method)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	app, err := NewFirecrawlApp("fc-test", "https://api.firecrawl.dev")
+	require.NoError(t, err)
+
+	sitemaps, err := app.DiscoverSitemaps(context.Background(), server.URL+"/blog/post?page=2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/sitemap_index.xml", "https://example.com/news.xml"}, sitemaps)
+
+	robots = ""
+	sitemaps, err = app.DiscoverSitemaps(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Nil(t, sitemaps)
+
+	hasSitemap = true
+	sitemaps, err = app.DiscoverSitemaps(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, []string{server.URL + "/sitemap.xml"}, sitemaps)
+
+	_, err = app.DiscoverSitemaps(context.Background(), "example.com")
+	assert.ErrorContains(t, err, "invalid site URL")
+}
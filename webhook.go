@@ -0,0 +1,215 @@
+package firecrawl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Webhook event types sent by the Firecrawl API for crawl jobs.
+const (
+	WebhookEventCrawlStarted   = "crawl.started"
+	WebhookEventCrawlPage      = "crawl.page"
+	WebhookEventCrawlCompleted = "crawl.completed"
+	WebhookEventCrawlFailed    = "crawl.failed"
+)
+
+// WebhookEvent represents a webhook payload delivered by the Firecrawl API.
+type WebhookEvent struct {
+	Success  bool                 `json:"success"`
+	Type     string               `json:"type"`
+	ID       string               `json:"id"`
+	Data     []*FirecrawlDocument `json:"data,omitempty"`
+	Metadata map[string]any       `json:"metadata,omitempty"`
+	Error    *string              `json:"error,omitempty"`
+}
+
+// ParseWebhookEvent decodes a webhook payload sent by the Firecrawl API.
+//
+// Parameters:
+//   - r: The webhook request body.
+//
+// Returns:
+//   - *WebhookEvent: The decoded webhook event.
+//   - error: An error if the payload cannot be decoded or has no event type.
+func ParseWebhookEvent(r io.Reader) (*WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.NewDecoder(r).Decode(&event); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook event: %v", err)
+	}
+	if event.Type == "" {
+		return nil, fmt.Errorf("invalid webhook event: missing type")
+	}
+	return &event, nil
+}
+
+// WaitForCrawlWebhook blocks until the crawl job with the given ID completes or fails,
+// assembling the documents delivered by its "crawl.page" events. It never polls the
+// Firecrawl API; events must be fed to the channel by the caller's webhook handler.
+//
+// Events for other jobs are read from the channel and discarded, so the channel must carry the
+// events of this job only: use one channel per job, routed by WebhookEvent.ID, or wait with
+// WebhookDispatcher.WaitForCrawl, which does the routing.
+//
+// Parameters:
+//   - ctx: The context used to stop waiting.
+//   - events: The channel of the job's webhook events received by the caller's webhook handler.
+//   - ID: The ID of the crawl job to wait for.
+//
+// Returns:
+//   - *CrawlStatusResponse: The crawl result assembled from the received events.
+//   - error: An error if the crawl fails, the channel is closed, or the context is done.
+func WaitForCrawlWebhook(ctx context.Context, events <-chan *WebhookEvent, ID string) (*CrawlStatusResponse, error) {
+	result := &CrawlStatusResponse{Status: "scraping"}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil, fmt.Errorf("webhook event channel closed before crawl job %s finished", ID)
+			}
+			if event == nil || event.ID != ID {
+				continue
+			}
+
+			switch event.Type {
+			case WebhookEventCrawlPage:
+				result.Data = append(result.Data, event.Data...)
+				result.Completed = len(result.Data)
+			case WebhookEventCrawlCompleted:
+				result.Data = append(result.Data, event.Data...)
+				result.Completed = len(result.Data)
+				result.Total = len(result.Data)
+				result.Status = "completed"
+				return result, nil
+			case WebhookEventCrawlFailed:
+				message := "No additional error details provided."
+				if event.Error != nil {
+					message = *event.Error
+				}
+				return nil, fmt.Errorf("crawl job failed. %s", message)
+			}
+		}
+	}
+}
+
+// WebhookDispatcher is an http.Handler that parses webhook events sent by the Firecrawl API and
+// routes each one to the handlers registered for its type, and to the WaitForCrawl calls waiting
+// for its job. Events without a handler or a waiter are acknowledged and dropped. It is safe to
+// register handlers while serving requests. The zero value is ready to use.
+type WebhookDispatcher struct {
+	mu       sync.RWMutex
+	handlers map[string][]func(*WebhookEvent)
+	waiters  map[string][]*webhookWaiter
+}
+
+// webhookWaiter receives the events of a job for a WaitForCrawl call until done is closed.
+type webhookWaiter struct {
+	events chan *WebhookEvent
+	done   chan struct{}
+}
+
+// NewWebhookDispatcher returns a WebhookDispatcher with no handlers registered.
+//
+// Returns:
+//   - *WebhookDispatcher: A new webhook dispatcher.
+func NewWebhookDispatcher() *WebhookDispatcher {
+	return &WebhookDispatcher{handlers: make(map[string][]func(*WebhookEvent))}
+}
+
+// Handle registers a handler for webhook events of the given type. Handlers registered for the
+// same type are called in the order they were registered.
+//
+// Parameters:
+//   - eventType: The event type to handle, e.g. WebhookEventCrawlPage.
+//   - handler: The function called with each event of that type.
+func (d *WebhookDispatcher) Handle(eventType string, handler func(*WebhookEvent)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.handlers == nil {
+		d.handlers = make(map[string][]func(*WebhookEvent))
+	}
+	d.handlers[eventType] = append(d.handlers[eventType], handler)
+}
+
+// WaitForCrawl blocks until the crawl job with the given ID completes or fails, like
+// WaitForCrawlWebhook, reading the job's events as the dispatcher serves them. Any number of jobs
+// can be waited for concurrently. Events that arrive before WaitForCrawl is called are not
+// delivered to it, so call it as soon as the job is started.
+//
+// Parameters:
+//   - ctx: The context used to stop waiting.
+//   - ID: The ID of the crawl job to wait for.
+//
+// Returns:
+//   - *CrawlStatusResponse: The crawl result assembled from the received events.
+//   - error: An error if the crawl fails or the context is done.
+func (d *WebhookDispatcher) WaitForCrawl(ctx context.Context, ID string) (*CrawlStatusResponse, error) {
+	waiter := &webhookWaiter{events: make(chan *WebhookEvent), done: make(chan struct{})}
+
+	d.mu.Lock()
+	if d.waiters == nil {
+		d.waiters = make(map[string][]*webhookWaiter)
+	}
+	d.waiters[ID] = append(d.waiters[ID], waiter)
+	d.mu.Unlock()
+
+	defer func() {
+		close(waiter.done)
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		waiters := d.waiters[ID]
+		for i, w := range waiters {
+			if w == waiter {
+				waiters = append(waiters[:i:i], waiters[i+1:]...)
+				break
+			}
+		}
+		if len(waiters) == 0 {
+			delete(d.waiters, ID)
+		} else {
+			d.waiters[ID] = waiters
+		}
+	}()
+
+	return WaitForCrawlWebhook(ctx, waiter.events, ID)
+}
+
+// ServeHTTP parses the webhook event in the request body and calls the handlers registered for
+// its type, then hands it to the WaitForCrawl calls waiting for its job, before responding.
+// Requests whose body is not a valid event get a 400 Bad Request.
+func (d *WebhookDispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	event, err := ParseWebhookEvent(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d.mu.RLock()
+	handlers := d.handlers[event.Type]
+	waiters := d.waiters[event.ID]
+	d.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+	for _, waiter := range waiters {
+		select {
+		case waiter.events <- event:
+		case <-waiter.done:
+		case <-r.Context().Done():
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
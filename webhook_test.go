@@ -0,0 +1,154 @@
+package firecrawl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWebhookEvent(t *testing.T) {
+	event, err := ParseWebhookEvent(strings.NewReader(`{"success":true,"type":"crawl.page","id":"job-1","data":[{"markdown":"# Hello"}]}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, WebhookEventCrawlPage, event.Type)
+	assert.Equal(t, "job-1", event.ID)
+	require.Len(t, event.Data, 1)
+	assert.Equal(t, "# Hello", event.Data[0].Markdown)
+}
+
+func TestParseWebhookEventMissingType(t *testing.T) {
+	_, err := ParseWebhookEvent(strings.NewReader(`{"success":true,"id":"job-1"}`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing type")
+}
+
+func TestWaitForCrawlWebhook(t *testing.T) {
+	events := make(chan *WebhookEvent, 4)
+	events <- &WebhookEvent{Type: WebhookEventCrawlPage, ID: "other", Data: []*FirecrawlDocument{{Markdown: "other"}}}
+	events <- &WebhookEvent{Type: WebhookEventCrawlPage, ID: "job-1", Data: []*FirecrawlDocument{{Markdown: "one"}}}
+	events <- &WebhookEvent{Type: WebhookEventCrawlPage, ID: "job-1", Data: []*FirecrawlDocument{{Markdown: "two"}}}
+	events <- &WebhookEvent{Type: WebhookEventCrawlCompleted, ID: "job-1"}
+
+	response, err := WaitForCrawlWebhook(context.Background(), events, "job-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "completed", response.Status)
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, "one", response.Data[0].Markdown)
+	assert.Equal(t, "two", response.Data[1].Markdown)
+}
+
+func TestWaitForCrawlWebhookFailed(t *testing.T) {
+	events := make(chan *WebhookEvent, 1)
+	events <- &WebhookEvent{Type: WebhookEventCrawlFailed, ID: "job-1", Error: ptr("boom")}
+
+	_, err := WaitForCrawlWebhook(context.Background(), events, "job-1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "crawl job failed. boom")
+}
+
+func TestWaitForCrawlWebhookContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitForCrawlWebhook(ctx, make(chan *WebhookEvent), "job-1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	dispatcher := NewWebhookDispatcher()
+
+	var pages []string
+	var completed []string
+	dispatcher.Handle(WebhookEventCrawlPage, func(event *WebhookEvent) {
+		pages = append(pages, event.Data[0].Markdown)
+	})
+	dispatcher.Handle(WebhookEventCrawlCompleted, func(event *WebhookEvent) {
+		completed = append(completed, event.ID)
+	})
+
+	send := func(method, body string) int {
+		recorder := httptest.NewRecorder()
+		dispatcher.ServeHTTP(recorder, httptest.NewRequest(method, "/firecrawl", strings.NewReader(body)))
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send(http.MethodPost, `{"success":true,"type":"crawl.started","id":"job-1"}`))
+	assert.Equal(t, http.StatusOK, send(http.MethodPost, `{"success":true,"type":"crawl.page","id":"job-1","data":[{"markdown":"# One"}]}`))
+	assert.Equal(t, http.StatusOK, send(http.MethodPost, `{"success":true,"type":"crawl.completed","id":"job-1"}`))
+	assert.Equal(t, http.StatusBadRequest, send(http.MethodPost, `{"success":true}`))
+	assert.Equal(t, http.StatusMethodNotAllowed, send(http.MethodGet, ""))
+
+	assert.Equal(t, []string{"# One"}, pages)
+	assert.Equal(t, []string{"job-1"}, completed)
+}
+
+func TestWebhookDispatcherZeroValue(t *testing.T) {
+	var dispatcher WebhookDispatcher
+
+	recorder := httptest.NewRecorder()
+	dispatcher.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/firecrawl", strings.NewReader(`{"success":true,"type":"crawl.started","id":"job-1"}`)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var started []string
+	dispatcher.Handle(WebhookEventCrawlStarted, func(event *WebhookEvent) {
+		started = append(started, event.ID)
+	})
+
+	recorder = httptest.NewRecorder()
+	dispatcher.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/firecrawl", strings.NewReader(`{"success":true,"type":"crawl.started","id":"job-1"}`)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, []string{"job-1"}, started)
+}
+
+func TestWebhookDispatcherWaitForCrawl(t *testing.T) {
+	dispatcher := NewWebhookDispatcher()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type result struct {
+		response *CrawlStatusResponse
+		err      error
+	}
+	results := map[string]chan result{"job-1": make(chan result, 1), "job-2": make(chan result, 1)}
+	for ID, ch := range results {
+		go func(ID string, ch chan result) {
+			response, err := dispatcher.WaitForCrawl(ctx, ID)
+			ch <- result{response, err}
+		}(ID, ch)
+	}
+	require.Eventually(t, func() bool {
+		dispatcher.mu.RLock()
+		defer dispatcher.mu.RUnlock()
+		return len(dispatcher.waiters) == 2
+	}, time.Second, time.Millisecond)
+
+	send := func(body string) {
+		recorder := httptest.NewRecorder()
+		dispatcher.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/firecrawl", strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, recorder.Code)
+	}
+	send(`{"success":true,"type":"crawl.page","id":"job-2","data":[{"markdown":"two"}]}`)
+	send(`{"success":true,"type":"crawl.page","id":"job-1","data":[{"markdown":"one"}]}`)
+	send(`{"success":true,"type":"crawl.page","id":"job-3","data":[{"markdown":"three"}]}`)
+	send(`{"success":true,"type":"crawl.completed","id":"job-1"}`)
+	send(`{"success":true,"type":"crawl.failed","id":"job-2","error":"boom"}`)
+
+	one := <-results["job-1"]
+	require.NoError(t, one.err)
+	require.Len(t, one.response.Data, 1)
+	assert.Equal(t, "one", one.response.Data[0].Markdown)
+
+	two := <-results["job-2"]
+	assert.ErrorContains(t, two.err, "boom")
+
+	dispatcher.mu.RLock()
+	defer dispatcher.mu.RUnlock()
+	assert.Empty(t, dispatcher.waiters)
+}